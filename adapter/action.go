@@ -1,17 +1,33 @@
 package adapter
 
 import (
+	"fmt"
+	"os"
+
 	plugin "github.com/hashicorp/go-plugin"
 )
 
-func StartAction(action Action) {
+func StartAction(action Action, opts ...StartOption) {
+	c := newStartConfig(opts)
+
+	if describeRequested() {
+		if err := writeManifest(os.Stdout, c.manifest, KindAction, DescribeAction(action)); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to describe the plugin: %v\n", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: ActionHandshake,
 		Plugins: map[string]plugin.Plugin{
-			"action": &ActionPlugin{Impl: action},
+			"action": &ActionPlugin{Impl: action, Limits: c.limits, Manifest: c.manifest},
 		},
 
 		// A non-nil value here enables gRPC serving for this plugin...
 		GRPCServer: plugin.DefaultGRPCServer,
 	})
+
+	pipelines.flush()
 }
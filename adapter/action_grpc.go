@@ -1,88 +1,345 @@
 package adapter
 
 import (
-	"github.com/hashicorp/go-plugin"
+	"fmt"
+
 	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GRPCClient is an implementation of KV that talks over RPC.
 type GRPCActionClient struct {
-	broker *plugin.GRPCBroker
+	broker Broker
 	client proto.ActionClient
+	stubs  persistentStubServer
+	bodies chunkClient
+
+	description descriptionCache
+}
+
+// Unhealthy returns the *FatalError of the first Fatalf or Panicf call of the plugin, and nil if it made none.
+func (m *GRPCActionClient) Unhealthy() error {
+	return m.stubs.health.unhealthy()
+}
+
+// SetLogLevel sets the lowest level that the plugin logs, overriding the level of the logger of its stub. The plugin
+// drops the records below it without sending them to the host.
+func (m *GRPCActionClient) SetLogLevel(level logger.Level) {
+	m.stubs.level.update(level)
 }
 
 func (m *GRPCActionClient) Init(stub Stub, cfg []byte) error {
+	return m.InitContext(context.Background(), stub, cfg)
+}
 
-	_, err := m.client.Init(context.Background(), &proto.InitActionRequest{
-		Config:     cfg,
+func (m *GRPCActionClient) InitContext(ctx context.Context, stub Stub, cfg []byte) error {
+	_, err := m.client.Init(ctx, &proto.InitActionRequest{
+		StubServer:       m.stubs.open(m.broker, stub),
+		Config:           cfg,
+		HostCapabilities: hostCapabilities,
 	})
 
-	return err
-}
+	if err != nil {
+		m.stubs.close()
+	}
 
+	return errorFromCall(ctx, err)
+}
 
 func (m *GRPCActionClient) Invoke(stub Stub, message *Message) error {
-	brokerID, closer := SetupStubServer(stub, m.broker)
+	return m.InvokeContext(context.Background(), stub, message)
+}
+
+func (m *GRPCActionClient) InvokeContext(ctx context.Context, stub Stub, message *Message) error {
+	brokerID, closer := m.stubs.get(m.broker, stub)
 	defer closer()
 
-	imsg, err := m.client.Invoke(context.Background(), &proto.InvokeRequest{
+	messages, err := m.bodies.toProto(ctx, message)
+
+	if err != nil {
+		return err
+	}
+
+	imsg, err := m.client.Invoke(ctx, &proto.InvokeRequest{
 		StubServer: brokerID,
-		Message: &proto.AdapterMessage{
-			Body:       message.Body,
-			Attributes: message.Attributes,
-		},
+		Message:    messages[0],
 	})
 
+	if err != nil {
+		return errorFromCall(ctx, err)
+	}
+
+	invoked, err := m.bodies.fromProto(ctx, imsg.Message)
+
 	if err != nil {
 		return err
 	}
 
-	message.Body = imsg.Message.Body
-	message.Attributes = imsg.Message.Attributes
+	// plugins that predate typed attributes return the body and the bool attributes only
+	if legacy, _ := isLegacy(m.Describe(ctx)); legacy {
+		invoked = mergeLegacyResponse(message, invoked)
+	}
+
+	*message = *invoked
 
 	return nil
 }
 
+func (m *GRPCActionClient) InvokeMulti(stub Stub, message *Message) ([]*Message, error) {
+	return m.InvokeMultiContext(context.Background(), stub, message)
+}
+
+// InvokeMultiContext returns the messages that the plugin replaced `message` with. Plugins that predate InvokeMulti
+// are invoked with Invoke instead.
+func (m *GRPCActionClient) InvokeMultiContext(ctx context.Context, stub Stub, message *Message) ([]*Message, error) {
+	legacy, err := isLegacy(m.Describe(ctx))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if legacy {
+		if err := m.InvokeContext(ctx, stub, message); err != nil {
+			return nil, err
+		}
+
+		return []*Message{message}, nil
+	}
+
+	brokerID, closer := m.stubs.get(m.broker, stub)
+	defer closer()
+
+	converted, err := m.bodies.toProto(ctx, message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := m.client.InvokeMulti(ctx, &proto.InvokeRequest{
+		StubServer: brokerID,
+		Message:    converted[0],
+	})
+
+	if err != nil {
+		return nil, errorFromCall(ctx, err)
+	}
+
+	messages := make([]*Message, len(r.Messages))
+
+	for i, message := range r.Messages {
+		if messages[i], err = m.bodies.fromProto(ctx, message); err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+func (m *GRPCActionClient) InvokeBatch(stub Stub, messages []*Message) ([]error, error) {
+	return m.InvokeBatchContext(context.Background(), stub, messages)
+}
+
+// InvokeBatchContext invokes all messages in a single call. Plugins that predate InvokeBatch get one Invoke per
+// message.
+func (m *GRPCActionClient) InvokeBatchContext(ctx context.Context, stub Stub, messages []*Message) ([]error, error) {
+	legacy, err := isLegacy(m.Describe(ctx))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if legacy {
+		return invokeEach(ctx, m, stub, messages), nil
+	}
+
+	brokerID, closer := m.stubs.get(m.broker, stub)
+	defer closer()
+
+	converted, err := m.bodies.toProto(ctx, messages...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := m.client.InvokeBatch(ctx, &proto.InvokeBatchRequest{
+		StubServer: brokerID,
+		Messages:   converted,
+	})
+
+	if err != nil {
+		return nil, errorFromCall(ctx, err)
+	}
+
+	if len(r.Results) != len(messages) {
+		return nil, fmt.Errorf("adapter: InvokeBatch returned %d results for %d messages", len(r.Results), len(messages))
+	}
+
+	errs := make([]error, len(r.Results))
+
+	for i, item := range r.Results {
+		if errs[i] = batchItemError(item); errs[i] != nil {
+			continue
+		}
+
+		invoked, err := m.bodies.fromProto(ctx, item.GetMessage())
+
+		if err != nil {
+			errs[i] = err
+		} else if invoked != nil {
+			*messages[i] = *invoked
+		}
+	}
+
+	return errs, nil
+}
+
+// Close asks the plugin to deliver the logs and metrics that it buffered for the stub that was handed to it at Init,
+// and then stops serving that stub. Plugins that predate the Close RPC are only cut off.
+func (m *GRPCActionClient) Close() error {
+	defer m.stubs.close()
+
+	_, err := m.client.Close(context.Background(), &proto.CloseActionRequest{})
+
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+
+	return errorFromStatus(err)
+}
+
 // Here is the gRPC server that GRPCClient talks to.
 type GRPCActionServer struct {
 	// This is the real implementation
-	Impl   Action
-	broker *plugin.GRPCBroker
+	Impl     Action
+	broker   Broker
+	stubs    persistentStubClient
+	bodies   chunkServer
+	manifest Manifest
 }
 
 func (m *GRPCActionServer) Init(ctx context.Context, req *proto.InitActionRequest) (*proto.InitActionResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	m.bodies.accept(req.HostCapabilities)
+
+	stub, err := m.stubs.open(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
-	defer closer()
+	err = NewContextAction(m.Impl).InitContext(ctx, stub, req.Config)
 
-	return &proto.InitActionResponse{}, m.Impl.Init(stub, req.Config)
+	if err != nil {
+		m.stubs.disconnect()
+	} else {
+		m.stubs.flush()
+	}
+
+	return &proto.InitActionResponse{}, errorToStatus(err)
 }
 
 func (m *GRPCActionServer) Invoke(ctx context.Context, req *proto.InvokeRequest) (*proto.InvokeResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	defer closer()
 
-	msg := &Message{
-		Body:       req.Message.Body,
-		Attributes: req.Message.Attributes,
+	msg, err := m.bodies.fromProto(req.Message)
+
+	if err != nil {
+		return nil, errorToStatus(err)
 	}
 
-	err = m.Impl.Invoke(stub, msg)
+	if err := NewContextAction(m.Impl).InvokeContext(ctx, stub, msg); err != nil {
+		return nil, errorToStatus(err)
+	}
 
 	return &proto.InvokeResponse{
-		Message: &proto.AdapterMessage{
-			Body:       msg.Body,
-			Attributes: msg.Attributes,
-		},
-	}, err
+		Message: m.bodies.toProto(msg)[0],
+	}, nil
+}
+
+func (m *GRPCActionServer) InvokeMulti(ctx context.Context, req *proto.InvokeRequest) (*proto.InvokeMultiResponse, error) {
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	defer closer()
+
+	message, err := m.bodies.fromProto(req.Message)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	messages, err := InvokeMulti(ctx, m.Impl, stub, message)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &proto.InvokeMultiResponse{
+		Messages: m.bodies.toProto(messages...),
+	}, nil
+}
+
+func (m *GRPCActionServer) InvokeBatch(ctx context.Context, req *proto.InvokeBatchRequest) (*proto.InvokeBatchResponse, error) {
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	defer closer()
+
+	messages := make([]*Message, len(req.Messages))
+
+	for i, message := range req.Messages {
+		if messages[i], err = m.bodies.fromProto(message); err != nil {
+			return nil, errorToStatus(err)
+		}
+	}
+
+	errs, err := InvokeBatch(ctx, m.Impl, stub, messages)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	r := &proto.InvokeBatchResponse{}
+
+	// the host discards the messages that failed, so only the invoked ones are returned
+	for i, err := range errs {
+		if err != nil {
+			messages[i] = nil
+		}
+	}
+
+	for i, message := range m.bodies.toProto(messages...) {
+		r.Results = append(r.Results, batchItemToProto(message, errs[i]))
+	}
+
+	return r, nil
+}
+
+// Close disconnects the persistent stub, delivering what was buffered for it while the host still serves it.
+func (m *GRPCActionServer) Close(ctx context.Context, req *proto.CloseActionRequest) (*proto.CloseActionResponse, error) {
+	m.stubs.disconnect()
+
+	return &proto.CloseActionResponse{}, nil
+}
+
+func (m *GRPCActionServer) UploadBody(srv proto.Action_UploadBodyServer) error {
+	return m.bodies.uploadBody(srv)
+}
+
+func (m *GRPCActionServer) DownloadBody(req *proto.DownloadBodyRequest, srv proto.Action_DownloadBodyServer) error {
+	return m.bodies.downloadBody(req, srv)
 }
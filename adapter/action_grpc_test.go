@@ -0,0 +1,225 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// initLogAction logs its config at Init.
+type initLogAction struct{}
+
+func (initLogAction) Init(stub Stub, config []byte) error {
+	stub.Printf("init with %s", config)
+
+	return nil
+}
+
+func (initLogAction) Invoke(stub Stub, message *Message) error { return nil }
+
+func dispenseAction(t testing.TB, impl Action) *GRPCActionClient {
+	client, _ := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"action": &ActionPlugin{Impl: impl},
+	})
+
+	raw, err := client.Dispense("action")
+
+	if err != nil {
+		t.Fatalf("failed to dispense action: %v", err)
+	}
+
+	return raw.(*GRPCActionClient)
+}
+
+func TestGRPCActionInitLogs(t *testing.T) {
+	action := dispenseAction(t, initLogAction{})
+	defer action.Close()
+
+	stub := &logStub{logs: make(chan string, 1)}
+
+	if err := action.Init(stub, []byte("config")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	select {
+	case log := <-stub.logs:
+		if log != "init with config" {
+			t.Fatalf("expected log %q, got %q", "init with config", log)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the action to log through the stub during Init")
+	}
+}
+
+// invokeLogAction logs at Invoke.
+type invokeLogAction struct {
+	initLogAction
+}
+
+func (invokeLogAction) Invoke(stub Stub, message *Message) error {
+	stub.Printf("invoked")
+
+	return nil
+}
+
+func TestGRPCActionCloseFlushesLogs(t *testing.T) {
+	action := dispenseAction(t, invokeLogAction{})
+	stub := &logStub{logs: make(chan string, 2)}
+
+	if err := action.Init(stub, []byte("config")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	if err := action.Invoke(stub, NewMessage(nil)); err != nil {
+		t.Fatalf("failed to invoke action: %v", err)
+	}
+
+	if err := action.Close(); err != nil {
+		t.Fatalf("failed to close action: %v", err)
+	}
+
+	// the log of Init has been delivered by the time Init returned
+	<-stub.logs
+
+	select {
+	case log := <-stub.logs:
+		if log != "invoked" {
+			t.Fatalf("expected log %q, got %q", "invoked", log)
+		}
+	default:
+		t.Fatalf("expected the log of Invoke to be delivered by the time Close returns")
+	}
+}
+
+func TestGRPCActionInitWithoutStubServer(t *testing.T) {
+	server := &GRPCActionServer{Impl: initLogAction{}}
+
+	_, err := server.Init(context.Background(), &proto.InitActionRequest{Config: []byte("config")})
+
+	if err == nil {
+		t.Fatalf("expected Init without a stub server to fail")
+	}
+}
+
+// dropAction drops every message.
+type dropAction struct {
+	suffixAction
+}
+
+func (dropAction) InvokeMulti(stub Stub, message *Message) ([]*Message, error) {
+	return nil, nil
+}
+
+func TestGRPCActionInvokeMulti(t *testing.T) {
+	dropping := dispenseAction(t, &dropAction{})
+	defer dropping.Close()
+
+	messages, err := dropping.InvokeMulti(testStub{}, NewMessage([]byte("a")))
+
+	if err != nil || len(messages) != 0 {
+		t.Fatalf("expected the message to be dropped, got %v, %v", messages, err)
+	}
+
+	plain := dispenseAction(t, &suffixAction{})
+	defer plain.Close()
+
+	if err := plain.Init(testStub{}, []byte("!")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	messages, err = InvokeMulti(context.Background(), plain, testStub{}, NewMessage([]byte("a")))
+
+	if err != nil || len(messages) != 1 || string(messages[0].Body) != "a!" {
+		t.Fatalf("expected a plain action to return the invoked message, got %v, %v", messages, err)
+	}
+}
+
+// legacyActionClient is the client of an action plugin that predates Describe, and whose responses only carry the
+// body and the bool attributes.
+type legacyActionClient struct {
+	proto.ActionClient
+}
+
+func (c legacyActionClient) Describe(ctx context.Context, in *proto.DescribeRequest, opts ...grpc.CallOption) (*proto.DescribeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method Describe")
+}
+
+func (c legacyActionClient) Invoke(ctx context.Context, in *proto.InvokeRequest, opts ...grpc.CallOption) (*proto.InvokeResponse, error) {
+	r, err := c.ActionClient.Invoke(ctx, in, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.Message = &proto.AdapterMessage{Body: r.Message.Body, Attributes: r.Message.Attributes}
+
+	return r, nil
+}
+
+func TestGRPCActionLegacyResponse(t *testing.T) {
+	action := dispenseAction(t, &suffixAction{})
+	defer action.Close()
+
+	action.client = legacyActionClient{ActionClient: action.client}
+
+	if err := action.Init(testStub{}, []byte("b")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	message := NewMessage([]byte("a"))
+	message.Headers["header"] = "value"
+	message.Attributes["string"] = StringAttr("value")
+	message.Attributes["bool"] = BoolAttr(true)
+	id := message.ID
+
+	if err := action.Invoke(testStub{}, message); err != nil {
+		t.Fatalf("failed to invoke action: %v", err)
+	}
+
+	if string(message.Body) != "ab" || message.ID != id || message.Headers["header"] != "value" {
+		t.Fatalf("expected the ID and the headers of the request to be kept, got %+v", message)
+	}
+
+	if s, _ := message.Attributes["string"].StringValue(); s != "value" {
+		t.Fatalf("expected the string attribute of the request to be kept, got %+v", message.Attributes)
+	}
+
+	if b, _ := message.Attributes["bool"].BoolValue(); !b {
+		t.Fatalf("expected the bool attribute of the response, got %+v", message.Attributes)
+	}
+}
+
+// clearingAction removes the headers and attributes of every message.
+type clearingAction struct {
+	initLogAction
+}
+
+func (clearingAction) Invoke(stub Stub, message *Message) error {
+	message.Headers = nil
+	message.Attributes = nil
+
+	return nil
+}
+
+func TestGRPCActionClearsAttributes(t *testing.T) {
+	action := dispenseAction(t, clearingAction{})
+	defer action.Close()
+
+	message := NewMessage([]byte("a"))
+	message.Headers["header"] = "value"
+	message.Attributes["secret"] = StringAttr("value")
+
+	if err := action.Invoke(testStub{}, message); err != nil {
+		t.Fatalf("failed to invoke action: %v", err)
+	}
+
+	if len(message.Headers) != 0 || len(message.Attributes) != 0 {
+		t.Fatalf("expected the headers and attributes that the action removed to stay removed, got %+v", message)
+	}
+}
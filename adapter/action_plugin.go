@@ -11,7 +11,7 @@ import (
 
 // ActionHandshake is a common handshake that is shared by plugin and host.
 var ActionHandshake = plugin.HandshakeConfig{
-	ProtocolVersion:  2,
+	ProtocolVersion:  LegacyProtocolVersion,
 	MagicCookieKey:   "ADAPTER_PLUGIN",
 	MagicCookieValue: "action",
 }
@@ -29,21 +29,52 @@ type ActionPlugin struct {
 	// Concrete implementation, written in Go. This is  only used for plugins
 	// that are written in Go.
 	Impl Action
+
+	// Limits bound the message bodies that are streamed in chunks; the zero value uses the defaults.
+	Limits Limits
+
+	// Manifest is what the plugin declares about itself; only used by the plugin.
+	Manifest Manifest
 }
 
 func (p *ActionPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
-	proto.RegisterActionServer(s, &GRPCActionServer{
-		Impl:   p.Impl,
-		broker: broker,
-	})
+	registerActionServer(s, broker, p)
 	return nil
 }
 
 func (p *ActionPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
-	return &GRPCActionClient{
+	return newActionClient(c, broker, p.Limits), nil
+}
+
+// registerActionServer serves the implementation of `p` on `s`. The server reaches the stub of the host through
+// `broker`.
+func registerActionServer(s *grpc.Server, broker Broker, p *ActionPlugin) {
+	proto.RegisterActionServer(s, &GRPCActionServer{
+		Impl:     p.Impl,
+		broker:   broker,
+		bodies:   chunkServer{limits: p.Limits},
+		manifest: p.Manifest,
+	})
+}
+
+func newActionClient(c *grpc.ClientConn, broker Broker, limits Limits) *GRPCActionClient {
+	m := &GRPCActionClient{
 		client: proto.NewActionClient(c),
 		broker: broker,
-	}, nil
+	}
+
+	m.bodies = chunkClient{
+		limits: limits,
+		upload: func(ctx context.Context) (bodyUploadClient, error) {
+			return m.client.UploadBody(ctx)
+		},
+		download: func(ctx context.Context, req *proto.DownloadBodyRequest) (bodyDownloadClient, error) {
+			return m.client.DownloadBody(ctx, req)
+		},
+		describe: m.Describe,
+	}
+
+	return m
 }
 
 var _ plugin.GRPCPlugin = &ActionPlugin{}
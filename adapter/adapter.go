@@ -1,6 +1,10 @@
 package adapter
 
-import "github.com/unchainio/interfaces/logger"
+import (
+	"context"
+
+	"github.com/unchainio/interfaces/logger"
+)
 
 type Endpoint interface {
 	// Init: must NOT block, start long running processes in a go routine
@@ -24,13 +28,110 @@ type Endpoint interface {
 	Close(stub Stub) error
 }
 
+// StreamingEndpoint is an Endpoint that pushes received messages to the adapter base as they arrive, instead of
+// waiting for a Receive call per message. Plain Endpoints can be turned into one with NewStreamingEndpoint.
+type StreamingEndpoint interface {
+	Endpoint
+
+	// ReceiveStream: must block, sending every received message on `messages` until receiving fails. It returns the
+	// error that ended the stream and must not close `messages`.
+	ReceiveStream(stub Stub, messages chan<- *TaggedMessage) error
+}
+
+// ContextEndpoint is the context-aware variant of Endpoint. The context carries the deadline and cancellation of the
+// call across the plugin boundary; implementations should return as soon as possible once it is done.
+// Endpoints can be converted with NewContextEndpoint and BackgroundEndpoint.
+type ContextEndpoint interface {
+	InitContext(ctx context.Context, stub Stub, config []byte) (err error)
+	SendContext(ctx context.Context, stub Stub, message *Message) (response *Message, err error)
+	ReceiveContext(ctx context.Context, stub Stub) (message *TaggedMessage, err error)
+	AckContext(ctx context.Context, stub Stub, tag uint64, response *Message) error
+	NackContext(ctx context.Context, stub Stub, tag uint64, err error) error
+	CloseContext(ctx context.Context, stub Stub) error
+}
+
+// ContextStreamingEndpoint is the context-aware variant of StreamingEndpoint. ReceiveStreamContext must return once
+// `ctx` is done.
+type ContextStreamingEndpoint interface {
+	ContextEndpoint
+
+	ReceiveStreamContext(ctx context.Context, stub Stub, messages chan<- *TaggedMessage) error
+}
+
+// BatchEndpoint is an Endpoint that can send many messages in a single call, e.g. to insert them into a database in
+// one transaction. Hosts send batches to any Endpoint with SendBatch, which falls back to one Send per message.
+type BatchEndpoint interface {
+	Endpoint
+
+	// SendBatch: must block until sending is complete. It returns a result per message, in order; `err` is only set
+	// if the batch as a whole failed.
+	SendBatch(stub Stub, messages []*Message) (results []BatchResult, err error)
+}
+
+// ContextBatchEndpoint is the context-aware variant of BatchEndpoint.
+type ContextBatchEndpoint interface {
+	ContextEndpoint
+
+	SendBatchContext(ctx context.Context, stub Stub, messages []*Message) (results []BatchResult, err error)
+}
+
 type Action interface {
 	Init(stub Stub, config []byte) (err error)
 	Invoke(stub Stub, message *Message) (err error)
 }
 
+// ContextAction is the context-aware variant of Action.
+// Actions can be converted with NewContextAction and BackgroundAction.
+type ContextAction interface {
+	InitContext(ctx context.Context, stub Stub, config []byte) (err error)
+	InvokeContext(ctx context.Context, stub Stub, message *Message) (err error)
+}
+
+// BatchAction is an Action that can invoke many messages in a single call. Hosts invoke batches on any Action with
+// InvokeBatch, which falls back to one Invoke per message.
+type BatchAction interface {
+	Action
+
+	// InvokeBatch modifies the messages in place like Invoke. It returns an error per message, in order, which is nil
+	// for the messages that have been invoked successfully; `err` is only set if the batch as a whole failed.
+	InvokeBatch(stub Stub, messages []*Message) (errs []error, err error)
+}
+
+// ContextBatchAction is the context-aware variant of BatchAction.
+type ContextBatchAction interface {
+	ContextAction
+
+	InvokeBatchContext(ctx context.Context, stub Stub, messages []*Message) (errs []error, err error)
+}
+
+// MultiAction is an Action that can drop a message or turn it into several, e.g. by splitting a JSON array into
+// records. Hosts invoke any Action this way with InvokeMulti, which falls back to Invoke for plain Actions.
+type MultiAction interface {
+	Action
+
+	// InvokeMulti returns the messages that replace `message`; returning none drops it
+	InvokeMulti(stub Stub, message *Message) (messages []*Message, err error)
+}
+
+// ContextMultiAction is the context-aware variant of MultiAction.
+type ContextMultiAction interface {
+	ContextAction
+
+	InvokeMultiContext(ctx context.Context, stub Stub, message *Message) (messages []*Message, err error)
+}
+
 type Stub interface {
+	// Logger logs through the host. Fatalf and Panicf terminate a plugin in a process of its own once the host has
+	// received the message, but never end the host, see FatalError.
 	logger.Logger
 
-	// TODO in the future this interface will also contain a kv store and a secret store
+	// KV is the key-value store of the plugin instance, for state that has to survive plugin restarts
+	KV
+
+	// Secrets gives access to the secrets, such as credentials, that the host provides to the plugin instance, so
+	// that they don't have to be part of its config. Their values are redacted from everything logged through the stub.
+	Secrets
+
+	// Metrics records the metrics of the plugin instance, which the host exposes in the Prometheus text format
+	Metrics
 }
@@ -1,6 +1,9 @@
 package adapter
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestNewTaggedMessage(t *testing.T) {
 	if tag := NewTaggedMessage(nil).Tag; tag != 1 {
@@ -15,3 +18,25 @@ func TestNewTaggedMessage(t *testing.T) {
 		t.Fatalf("expected tag %d, got %d", 3, tag)
 	}
 }
+
+func TestNewMessageID(t *testing.T) {
+	first, second := NewMessage(nil).ID, NewMessage(nil).ID
+
+	if len(first) != 26 || len(second) != 26 {
+		t.Fatalf("expected IDs of 26 characters, got %q and %q", first, second)
+	}
+
+	if first == second {
+		t.Fatalf("expected unique IDs, got %q twice", first)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	if third := NewMessage(nil).ID; third <= first {
+		t.Fatalf("expected ID %q to sort after %q", third, first)
+	}
+
+	if id := NewTaggedMessage(nil, WithID("upstream-1")).ID; id != "upstream-1" {
+		t.Fatalf("expected ID %q, got %q", "upstream-1", id)
+	}
+}
@@ -0,0 +1,60 @@
+package adaptertest
+
+import (
+	"testing"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// RunActionConformance checks that the actions returned by `factory` honor the contract of adapter.Action: Init
+// does not block, and Invoke completes for every message of WithMessages. `factory` must return a new, uninitialized
+// action every time it is called.
+func RunActionConformance(t *testing.T, factory func() adapter.Action, opts ...Option) {
+	o := newOptions(opts)
+
+	for _, tr := range transports {
+		tr := tr
+
+		t.Run(tr.name, func(t *testing.T) {
+			stub, stop := o.stubFor(t)
+			defer stop()
+
+			action, closeAction, err := tr.action(factory())
+
+			if err != nil {
+				t.Fatalf("failed to set up the action: %v", err)
+			}
+
+			defer closeAction()
+
+			err = within(t, o.timeout, "Init", func() error {
+				return action.Init(stub, o.config)
+			})
+
+			if err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+
+			for _, message := range o.messages {
+				invoked := adapter.NewMessage(append([]byte(nil), message.Body...))
+				invoked.ID = message.ID
+
+				for key, value := range message.Headers {
+					invoked.Headers[key] = value
+				}
+
+				for key, value := range message.Attributes {
+					invoked.Attributes[key] = value
+				}
+
+				err := within(t, o.timeout, "Invoke", func() error {
+					return action.Invoke(stub, invoked)
+				})
+
+				if err != nil {
+					t.Fatalf("Invoke of message %s failed: %v", message.ID, err)
+				}
+			}
+		})
+	}
+}
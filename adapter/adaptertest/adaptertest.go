@@ -0,0 +1,187 @@
+// Package adaptertest checks that endpoint and action implementations honor the contract documented on
+// adapter.Endpoint and adapter.Action. Every check runs over both the direct transport and the gRPC transport that
+// plugins use, so that implementations behave the same whether they run in-process or as a plugin.
+package adaptertest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// Option configures a conformance suite.
+type Option func(o *options)
+
+type options struct {
+	config   []byte
+	stub     adapter.Stub
+	timeout  time.Duration
+	deliver  func(body []byte) error
+	sent     func(body []byte) bool
+	noSend   bool
+	messages []*adapter.Message
+}
+
+// WithConfig passes `config` to Init. Defaults to nil.
+func WithConfig(config []byte) Option {
+	return func(o *options) {
+		o.config = config
+	}
+}
+
+// WithStub passes `stub` to every call. Defaults to a stub that logs to the test log, with an in-memory KV and no
+// secrets.
+func WithStub(stub adapter.Stub) Option {
+	return func(o *options) {
+		o.stub = stub
+	}
+}
+
+// WithTimeout sets how long a call may take before it is considered to block. Defaults to 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithDeliver enables the Receive, Ack and Nack checks of an endpoint. `deliver` must make a message with `body`
+// available to the endpoint returned by the factory most recently, e.g. by publishing it to the queue it consumes.
+// Every endpoint returned by the factory must start out with nothing to receive.
+func WithDeliver(deliver func(body []byte) error) Option {
+	return func(o *options) {
+		o.deliver = deliver
+	}
+}
+
+// WithSent enables checking that Send blocks until sending is complete: `sent` must report whether a message with
+// `body` has been sent by the endpoint returned by the factory most recently.
+func WithSent(sent func(body []byte) bool) Option {
+	return func(o *options) {
+		o.sent = sent
+	}
+}
+
+// WithoutSend disables the Send checks, for endpoints that only receive.
+func WithoutSend() Option {
+	return func(o *options) {
+		o.noSend = true
+	}
+}
+
+// WithMessages sets the messages that actions are invoked with. Defaults to a single message.
+func WithMessages(messages ...*adapter.Message) Option {
+	return func(o *options) {
+		o.messages = messages
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		timeout:  5 * time.Second,
+		messages: []*adapter.Message{adapter.NewMessage([]byte("conformance"))},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// stubFor returns the stub to use in the test `t`, and a function to call when the test is done.
+func (o *options) stubFor(t *testing.T) (adapter.Stub, func()) {
+	if o.stub != nil {
+		return o.stub, func() {}
+	}
+
+	log := &testLogger{t: t}
+
+	return adapter.NewStub(log), log.stop
+}
+
+// transport runs an implementation either directly or over gRPC.
+type transport struct {
+	name     string
+	endpoint func(endpoint adapter.Endpoint) (adapter.Endpoint, error)
+	action   func(action adapter.Action) (adapter.Action, func(), error)
+}
+
+var transports = []transport{
+	{
+		name: "direct",
+		endpoint: func(endpoint adapter.Endpoint) (adapter.Endpoint, error) {
+			return adapter.NewDirectEndpoint(endpoint), nil
+		},
+		action: func(action adapter.Action) (adapter.Action, func(), error) {
+			return adapter.NewDirectAction(action), func() {}, nil
+		},
+	},
+	{
+		name: "grpc",
+		endpoint: func(endpoint adapter.Endpoint) (adapter.Endpoint, error) {
+			return adapter.NewInProcessEndpoint(endpoint)
+		},
+		action: func(action adapter.Action) (adapter.Action, func(), error) {
+			a, err := adapter.NewInProcessAction(action)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return a, func() { a.Close() }, nil
+		},
+	},
+}
+
+// within runs `fn` and returns its error, failing `t` if it takes longer than `timeout`.
+func within(t *testing.T, timeout time.Duration, call string, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		t.Fatalf("%s blocked for longer than %v", call, timeout)
+
+		return nil
+	}
+}
+
+// testLogger logs to the log of a test until it is stopped, so that plugins logging from background goroutines don't
+// log after the test has completed.
+type testLogger struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+func (l *testLogger) logf(level, format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.stopped {
+		l.t.Logf("%s %s", level, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *testLogger) stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopped = true
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) { l.logf("INFO", format, v...) }
+func (l *testLogger) Fatalf(format string, v ...interface{}) { l.logf("FATAL", format, v...) }
+func (l *testLogger) Panicf(format string, v ...interface{}) { l.logf("PANIC", format, v...) }
+func (l *testLogger) Debugf(format string, v ...interface{}) { l.logf("DEBUG", format, v...) }
+func (l *testLogger) Warnf(format string, v ...interface{})  { l.logf("WARN", format, v...) }
+func (l *testLogger) Errorf(format string, v ...interface{}) { l.logf("ERROR", format, v...) }
@@ -0,0 +1,101 @@
+package adaptertest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// chanEndpoint receives the messages delivered to it and records the bodies it sends.
+type chanEndpoint struct {
+	messages chan *adapter.TaggedMessage
+	closed   chan struct{}
+
+	mu   sync.Mutex
+	sent map[string]bool
+}
+
+func newChanEndpoint() *chanEndpoint {
+	return &chanEndpoint{
+		messages: make(chan *adapter.TaggedMessage, 16),
+		closed:   make(chan struct{}),
+		sent:     make(map[string]bool),
+	}
+}
+
+func (e *chanEndpoint) Init(stub adapter.Stub, config []byte) error { return nil }
+
+func (e *chanEndpoint) Send(stub adapter.Stub, message *adapter.Message) (*adapter.Message, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.sent[string(message.Body)] = true
+
+	return nil, nil
+}
+
+func (e *chanEndpoint) Receive(stub adapter.Stub) (*adapter.TaggedMessage, error) {
+	select {
+	case message := <-e.messages:
+		return message, nil
+	case <-e.closed:
+		return nil, errors.New("closed")
+	}
+}
+
+func (e *chanEndpoint) Ack(stub adapter.Stub, tag uint64, response *adapter.Message) error {
+	return nil
+}
+
+func (e *chanEndpoint) Nack(stub adapter.Stub, tag uint64, err error) error { return nil }
+
+func (e *chanEndpoint) Close(stub adapter.Stub) error {
+	close(e.closed)
+
+	return nil
+}
+
+func TestRunEndpointConformance(t *testing.T) {
+	var endpoint *chanEndpoint
+
+	RunEndpointConformance(t, func() adapter.Endpoint {
+		endpoint = newChanEndpoint()
+
+		return endpoint
+	}, WithDeliver(func(body []byte) error {
+		endpoint.messages <- adapter.NewTaggedMessage(body, adapter.WithRandomTag())
+
+		return nil
+	}), WithSent(func(body []byte) bool {
+		endpoint.mu.Lock()
+		defer endpoint.mu.Unlock()
+
+		return endpoint.sent[string(body)]
+	}))
+}
+
+type upperAction struct{}
+
+func (upperAction) Init(stub adapter.Stub, config []byte) error {
+	stub.Printf("init with %s", config)
+
+	return nil
+}
+
+func (upperAction) Invoke(stub adapter.Stub, message *adapter.Message) error {
+	for i, b := range message.Body {
+		if 'a' <= b && b <= 'z' {
+			message.Body[i] = b - 'a' + 'A'
+		}
+	}
+
+	return nil
+}
+
+func TestRunActionConformance(t *testing.T) {
+	RunActionConformance(t, func() adapter.Action {
+		return upperAction{}
+	}, WithConfig([]byte("config")), WithMessages(adapter.NewMessage([]byte("a")), adapter.NewMessage(nil)))
+}
@@ -0,0 +1,183 @@
+package adaptertest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// receiveDelay is how long Receive has to keep blocking when nothing has been delivered.
+const receiveDelay = 100 * time.Millisecond
+
+// RunEndpointConformance checks that the endpoints returned by `factory` honor the contract of adapter.Endpoint:
+// Init does not block, Send blocks until sending is complete, Receive blocks until a message arrives, and received
+// tags can be Acked and Nacked. `factory` must return a new, uninitialized endpoint every time it is called. The
+// Receive checks only run with WithDeliver.
+func RunEndpointConformance(t *testing.T, factory func() adapter.Endpoint, opts ...Option) {
+	o := newOptions(opts)
+
+	for _, tr := range transports {
+		tr := tr
+
+		t.Run(tr.name, func(t *testing.T) {
+			run := func(name string, check func(t *testing.T, endpoint adapter.Endpoint, stub adapter.Stub)) {
+				t.Run(name, func(t *testing.T) {
+					stub, stop := o.stubFor(t)
+					defer stop()
+
+					endpoint, err := tr.endpoint(factory())
+
+					if err != nil {
+						t.Fatalf("failed to set up the endpoint: %v", err)
+					}
+
+					err = within(t, o.timeout, "Init", func() error {
+						return endpoint.Init(stub, o.config)
+					})
+
+					if err != nil {
+						t.Fatalf("Init failed: %v", err)
+					}
+
+					check(t, endpoint, stub)
+
+					err = within(t, o.timeout, "Close", func() error {
+						return endpoint.Close(stub)
+					})
+
+					if err != nil {
+						t.Fatalf("Close failed: %v", err)
+					}
+				})
+			}
+
+			run("InitClose", func(t *testing.T, endpoint adapter.Endpoint, stub adapter.Stub) {})
+
+			if !o.noSend {
+				run("Send", func(t *testing.T, endpoint adapter.Endpoint, stub adapter.Stub) {
+					checkSend(t, o, endpoint, stub)
+				})
+			}
+
+			if o.deliver != nil {
+				run("Receive", func(t *testing.T, endpoint adapter.Endpoint, stub adapter.Stub) {
+					checkReceive(t, o, endpoint, stub)
+				})
+
+				run("AckNack", func(t *testing.T, endpoint adapter.Endpoint, stub adapter.Stub) {
+					checkAckNack(t, o, endpoint, stub)
+				})
+			}
+		})
+	}
+}
+
+func checkSend(t *testing.T, o *options, endpoint adapter.Endpoint, stub adapter.Stub) {
+	body := []byte("conformance send")
+
+	err := within(t, o.timeout, "Send", func() error {
+		_, err := endpoint.Send(stub, adapter.NewMessage(body))
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if o.sent != nil && !o.sent(body) {
+		t.Fatalf("Send returned before the message was sent")
+	}
+}
+
+func checkReceive(t *testing.T, o *options, endpoint adapter.Endpoint, stub adapter.Stub) {
+	type result struct {
+		message *adapter.TaggedMessage
+		err     error
+	}
+
+	received := make(chan result, 1)
+
+	go func() {
+		message, err := endpoint.Receive(stub)
+		received <- result{message, err}
+	}()
+
+	select {
+	case r := <-received:
+		t.Fatalf("Receive returned before a message was delivered: %v, %v", r.message, r.err)
+	case <-time.After(receiveDelay):
+	}
+
+	body := []byte("conformance receive")
+
+	if err := o.deliver(body); err != nil {
+		t.Fatalf("failed to deliver a message: %v", err)
+	}
+
+	select {
+	case r := <-received:
+		if r.err != nil {
+			t.Fatalf("Receive failed: %v", r.err)
+		}
+
+		if r.message == nil || r.message.Message == nil || string(r.message.Body) != string(body) {
+			t.Fatalf("expected to receive %q, got %v", body, r.message)
+		}
+
+		if err := endpoint.Ack(stub, r.message.Tag, nil); err != nil {
+			t.Fatalf("failed to Ack tag %d: %v", r.message.Tag, err)
+		}
+	case <-time.After(o.timeout):
+		t.Fatalf("Receive did not return within %v after a message was delivered", o.timeout)
+	}
+}
+
+func checkAckNack(t *testing.T, o *options, endpoint adapter.Endpoint, stub adapter.Stub) {
+	bodies := []string{"conformance ack", "conformance nack"}
+
+	for _, body := range bodies {
+		if err := o.deliver([]byte(body)); err != nil {
+			t.Fatalf("failed to deliver a message: %v", err)
+		}
+	}
+
+	var tags []uint64
+
+	for range bodies {
+		var message *adapter.TaggedMessage
+
+		err := within(t, o.timeout, "Receive", func() (err error) {
+			message, err = endpoint.Receive(stub)
+
+			return err
+		})
+
+		if err != nil {
+			t.Fatalf("Receive failed: %v", err)
+		}
+
+		tags = append(tags, message.Tag)
+	}
+
+	if tags[0] == tags[1] {
+		t.Fatalf("expected received messages to have distinct tags, got %d twice", tags[0])
+	}
+
+	err := within(t, o.timeout, "Ack", func() error {
+		return endpoint.Ack(stub, tags[0], adapter.NewMessage([]byte("conformance response")))
+	})
+
+	if err != nil {
+		t.Fatalf("failed to Ack tag %d: %v", tags[0], err)
+	}
+
+	err = within(t, o.timeout, "Nack", func() error {
+		return endpoint.Nack(stub, tags[1], adapter.NewError("conformance", "nacked by the conformance suite"))
+	})
+
+	if err != nil {
+		t.Fatalf("failed to Nack tag %d: %v", tags[1], err)
+	}
+}
@@ -0,0 +1,109 @@
+package adapter
+
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+)
+
+// AttributeKind is the type of the value of a message attribute.
+type AttributeKind int
+
+const (
+	StringAttribute AttributeKind = iota + 1
+	IntAttribute
+	DoubleAttribute
+	BoolAttribute
+	BytesAttribute
+	TimeAttribute
+)
+
+// AttributeValue is the typed value of a message attribute. Values are constructed with StringAttr, IntAttr,
+// DoubleAttr, BoolAttr, BytesAttr and TimeAttr, and read with the accessor of their kind. The zero value has no kind.
+type AttributeValue struct {
+	kind AttributeKind
+	s    string
+	i    int64
+	d    float64
+	b    bool
+	raw  []byte
+	t    time.Time
+}
+
+func StringAttr(value string) AttributeValue {
+	return AttributeValue{kind: StringAttribute, s: value}
+}
+
+func IntAttr(value int64) AttributeValue {
+	return AttributeValue{kind: IntAttribute, i: value}
+}
+
+func DoubleAttr(value float64) AttributeValue {
+	return AttributeValue{kind: DoubleAttribute, d: value}
+}
+
+func BoolAttr(value bool) AttributeValue {
+	return AttributeValue{kind: BoolAttribute, b: value}
+}
+
+func BytesAttr(value []byte) AttributeValue {
+	return AttributeValue{kind: BytesAttribute, raw: value}
+}
+
+func TimeAttr(value time.Time) AttributeValue {
+	return AttributeValue{kind: TimeAttribute, t: value}
+}
+
+func (v AttributeValue) Kind() AttributeKind {
+	return v.kind
+}
+
+// StringValue returns the value of a StringAttribute, and false for any other kind.
+func (v AttributeValue) StringValue() (string, bool) {
+	return v.s, v.kind == StringAttribute
+}
+
+// IntValue returns the value of an IntAttribute, and false for any other kind.
+func (v AttributeValue) IntValue() (int64, bool) {
+	return v.i, v.kind == IntAttribute
+}
+
+// DoubleValue returns the value of a DoubleAttribute, and false for any other kind.
+func (v AttributeValue) DoubleValue() (float64, bool) {
+	return v.d, v.kind == DoubleAttribute
+}
+
+// BoolValue returns the value of a BoolAttribute, and false for any other kind.
+func (v AttributeValue) BoolValue() (value bool, ok bool) {
+	return v.b, v.kind == BoolAttribute
+}
+
+// BytesValue returns the value of a BytesAttribute, and false for any other kind.
+func (v AttributeValue) BytesValue() ([]byte, bool) {
+	return v.raw, v.kind == BytesAttribute
+}
+
+// TimeValue returns the value of a TimeAttribute, and false for any other kind.
+func (v AttributeValue) TimeValue() (time.Time, bool) {
+	return v.t, v.kind == TimeAttribute
+}
+
+// String formats the value of any kind, e.g. for logging. Bytes are base64 encoded and times formatted as RFC 3339.
+func (v AttributeValue) String() string {
+	switch v.kind {
+	case StringAttribute:
+		return v.s
+	case IntAttribute:
+		return strconv.FormatInt(v.i, 10)
+	case DoubleAttribute:
+		return strconv.FormatFloat(v.d, 'g', -1, 64)
+	case BoolAttribute:
+		return strconv.FormatBool(v.b)
+	case BytesAttribute:
+		return base64.StdEncoding.EncodeToString(v.raw)
+	case TimeAttribute:
+		return v.t.Format(time.RFC3339Nano)
+	}
+
+	return ""
+}
@@ -0,0 +1,129 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+)
+
+// BatchResult is the outcome of sending a single message of a batch.
+type BatchResult struct {
+	Response *Message
+	Err      error
+}
+
+// SendBatch sends `messages` over `endpoint` in a single call if it implements ContextBatchEndpoint or BatchEndpoint,
+// and with one Send per message otherwise. It returns a result per message, in order; the error is only set if the
+// batch as a whole failed.
+func SendBatch(ctx context.Context, endpoint Endpoint, stub Stub, messages []*Message) ([]BatchResult, error) {
+	var (
+		results []BatchResult
+		err     error
+	)
+
+	switch e := endpoint.(type) {
+	case ContextBatchEndpoint:
+		results, err = e.SendBatchContext(ctx, stub, messages)
+	case BatchEndpoint:
+		err = runContext(ctx, func() (err error) {
+			results, err = e.SendBatch(stub, messages)
+
+			return err
+		})
+	default:
+		return sendEach(ctx, NewContextEndpoint(endpoint), stub, messages), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(messages) {
+		return nil, fmt.Errorf("adapter: SendBatch returned %d results for %d messages", len(results), len(messages))
+	}
+
+	return results, nil
+}
+
+func sendEach(ctx context.Context, endpoint ContextEndpoint, stub Stub, messages []*Message) []BatchResult {
+	results := make([]BatchResult, len(messages))
+
+	for i, message := range messages {
+		results[i].Response, results[i].Err = endpoint.SendContext(ctx, stub, message)
+	}
+
+	return results
+}
+
+// InvokeBatch invokes `action` on `messages` in a single call if it implements ContextBatchAction or BatchAction, and
+// with one Invoke per message otherwise. The messages are modified in place like Invoke does. It returns an error per
+// message, in order, which is nil for the messages that have been invoked successfully; the error is only set if the
+// batch as a whole failed.
+func InvokeBatch(ctx context.Context, action Action, stub Stub, messages []*Message) ([]error, error) {
+	var (
+		errs []error
+		err  error
+	)
+
+	switch a := action.(type) {
+	case ContextBatchAction:
+		errs, err = a.InvokeBatchContext(ctx, stub, messages)
+	case BatchAction:
+		errs, err = invokeBatchContext(ctx, a, stub, messages)
+	default:
+		return invokeEach(ctx, NewContextAction(action), stub, messages), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errs) != len(messages) {
+		return nil, fmt.Errorf("adapter: InvokeBatch returned %d errors for %d messages", len(errs), len(messages))
+	}
+
+	return errs, nil
+}
+
+// invokeBatchContext runs InvokeBatch on copies of the messages, like contextAction does for Invoke, so that an
+// abandoned batch never modifies the messages after it has been abandoned.
+func invokeBatchContext(ctx context.Context, action BatchAction, stub Stub, messages []*Message) ([]error, error) {
+	if ctx.Done() == nil {
+		return action.InvokeBatch(stub, messages)
+	}
+
+	invoked := make([]*Message, len(messages))
+
+	for i, message := range messages {
+		invoked[i] = cloneMessage(message)
+	}
+
+	var errs []error
+
+	err := runContext(ctx, func() (err error) {
+		errs, err = action.InvokeBatch(stub, invoked)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range messages {
+		if i < len(errs) && errs[i] == nil {
+			*messages[i] = *invoked[i]
+		}
+	}
+
+	return errs, nil
+}
+
+func invokeEach(ctx context.Context, action ContextAction, stub Stub, messages []*Message) []error {
+	errs := make([]error, len(messages))
+
+	for i, message := range messages {
+		errs[i] = action.InvokeContext(ctx, stub, message)
+	}
+
+	return errs
+}
@@ -0,0 +1,133 @@
+package adapter
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+// batchEndpoint sends batches in a single call, and rejects messages with an empty body.
+type batchEndpoint struct {
+	queueEndpoint
+	batches int32
+}
+
+func (e *batchEndpoint) SendBatch(stub Stub, messages []*Message) ([]BatchResult, error) {
+	atomic.AddInt32(&e.batches, 1)
+
+	results := make([]BatchResult, len(messages))
+
+	for i, message := range messages {
+		if len(message.Body) == 0 {
+			results[i].Err = NewError("empty", "empty body")
+		} else {
+			results[i].Response = NewMessage(append([]byte("sent "), message.Body...))
+		}
+	}
+
+	return results, nil
+}
+
+func TestGRPCEndpointSendBatch(t *testing.T) {
+	impl := &batchEndpoint{}
+	endpoint := dispenseEndpoint(t, impl)
+
+	results, err := endpoint.SendBatch(testStub{}, []*Message{NewMessage([]byte("a")), NewMessage(nil)})
+
+	if err != nil {
+		t.Fatalf("failed to send batch: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Err != nil || string(results[0].Response.Body) != "sent a" {
+		t.Fatalf("expected the first message to be sent, got %+v", results)
+	}
+
+	if e, ok := AsError(results[1].Err); !ok || e.Code != "empty" {
+		t.Fatalf("expected the second message to fail with an *Error, got %v", results[1].Err)
+	}
+
+	if impl.batches != 1 {
+		t.Fatalf("expected a single SendBatch call, got %d", impl.batches)
+	}
+}
+
+func TestGRPCEndpointSendBatchFallback(t *testing.T) {
+	withoutRPC, _ := dispenseLegacyEndpoint(t, newQueueEndpoint())
+
+	for name, endpoint := range map[string]*GRPCEndpointClient{
+		"plugin without SendBatch": dispenseEndpoint(t, newQueueEndpoint()),
+		"plugin without the RPC":   withoutRPC,
+	} {
+		results, err := SendBatch(context.Background(), endpoint, testStub{}, []*Message{
+			NewMessage([]byte("a")),
+			NewMessage([]byte("b")),
+		})
+
+		if err != nil || len(results) != 2 {
+			t.Fatalf("%s: expected 2 results, got %+v, %v", name, results, err)
+		}
+
+		for i, body := range []string{"a", "b"} {
+			if results[i].Err != nil || string(results[i].Response.Body) != body {
+				t.Fatalf("%s: expected response %q, got %+v", name, body, results[i])
+			}
+		}
+	}
+}
+
+// batchSuffixAction appends its config to the body of every message in a batch, and fails on empty bodies.
+type batchSuffixAction struct {
+	suffixAction
+}
+
+func (a *batchSuffixAction) InvokeBatch(stub Stub, messages []*Message) ([]error, error) {
+	errs := make([]error, len(messages))
+
+	for i, message := range messages {
+		if len(message.Body) == 0 {
+			errs[i] = NewError("empty", "empty body")
+		} else {
+			errs[i] = a.Invoke(stub, message)
+		}
+	}
+
+	return errs, nil
+}
+
+func TestGRPCActionInvokeBatch(t *testing.T) {
+	for name, impl := range map[string]Action{
+		"batch":    &batchSuffixAction{},
+		"fallback": &suffixAction{},
+	} {
+		action := dispenseAction(t, impl)
+
+		if err := action.Init(testStub{}, []byte("!")); err != nil {
+			t.Fatalf("%s: failed to init action: %v", name, err)
+		}
+
+		messages := []*Message{NewMessage([]byte("a")), NewMessage([]byte("b"))}
+		errs, err := InvokeBatch(context.Background(), action, testStub{}, messages)
+
+		if err != nil || len(errs) != 2 || errs[0] != nil || errs[1] != nil {
+			t.Fatalf("%s: expected the batch to succeed, got %v, %v", name, errs, err)
+		}
+
+		if string(messages[0].Body) != "a!" || string(messages[1].Body) != "b!" {
+			t.Fatalf("%s: expected the messages to be invoked, got %q and %q", name, messages[0].Body, messages[1].Body)
+		}
+
+		action.Close()
+	}
+
+	action := dispenseAction(t, &batchSuffixAction{})
+	defer action.Close()
+
+	action.Init(testStub{}, []byte("!"))
+
+	messages := []*Message{NewMessage(nil)}
+	errs, err := action.InvokeBatch(testStub{}, messages)
+
+	if err != nil || !IsPermanent(errs[0]) {
+		t.Fatalf("expected the empty message to fail with a permanent error, got %v, %v", errs, err)
+	}
+}
@@ -81,3 +81,31 @@ func BenchmarkCryptoRand(b *testing.B) {
 		}
 	}
 }
+
+func benchmarkGRPCEndpointSend(b *testing.B, endpoint Endpoint) {
+	message := NewMessage([]byte(`{"amountReceived": "1.42343587970964396819"}`))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := endpoint.Send(testStub{}, message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGRPCEndpointSend(b *testing.B) {
+	endpoint := dispenseEndpoint(b, newQueueEndpoint())
+
+	if err := endpoint.Init(testStub{}, nil); err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarkGRPCEndpointSend(b, endpoint)
+}
+
+// BenchmarkGRPCEndpointSendPerCallStub never calls Init, so every Send sets up and tears down a stub server of its
+// own, which is what every call used to do before the stub connection was kept open from Init until Close.
+func BenchmarkGRPCEndpointSendPerCallStub(b *testing.B) {
+	benchmarkGRPCEndpointSend(b, dispenseEndpoint(b, newQueueEndpoint()))
+}
@@ -0,0 +1,397 @@
+package adapter
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	// DefaultChunkSize keeps every call well below the default maximum gRPC message size of 4 MiB.
+	DefaultChunkSize = 1 << 20
+
+	// DefaultMaxBodySize is the largest body that is accepted by default.
+	DefaultMaxBodySize = 64 << 20
+)
+
+// Limits bound the bodies of the messages that cross the plugin boundary. Bodies that don't fit in a call are streamed
+// in chunks ahead of it, and the call refers to them instead, so that no gRPC message exceeds the maximum gRPC
+// message size. Both the host and the plugin set their own limits, and each enforces its MaxBodySize on the bodies it
+// receives, inline or streamed.
+//
+// Plugins only stream the bodies they return once the host has told them that it supports it, at Init or Describe.
+// Hosts that predate chunking get every body inline, which bounds them by the maximum gRPC message size.
+type Limits struct {
+	// ChunkSize is the largest amount of body data that is sent in a single gRPC message; a call carries its bodies
+	// in the call itself while they add up to at most ChunkSize. Defaults to DefaultChunkSize.
+	ChunkSize int
+
+	// MaxBodySize is the largest body that is accepted. Defaults to DefaultMaxBodySize.
+	MaxBodySize int
+}
+
+func (l Limits) chunkSize() int {
+	if l.ChunkSize > 0 {
+		return l.ChunkSize
+	}
+
+	return DefaultChunkSize
+}
+
+func (l Limits) maxBodySize() int {
+	if l.MaxBodySize > 0 {
+		return l.MaxBodySize
+	}
+
+	return DefaultMaxBodySize
+}
+
+func errBodyTooLarge(max int) error {
+	return NewError("body_too_large", fmt.Sprintf("the body exceeds the maximum body size of %d bytes", max))
+}
+
+// checkBodySize returns an error if the inline body of `message` exceeds `max`.
+func checkBodySize(message *proto.AdapterMessage, max int) error {
+	if message != nil && len(message.Body) > max {
+		return errBodyTooLarge(max)
+	}
+
+	return nil
+}
+
+// hostCapabilities are the capabilities that the host tells the plugin about.
+var hostCapabilities = []string{string(CapabilityChunking)}
+
+type bodyUploadClient interface {
+	Send(*proto.BodyChunk) error
+	CloseAndRecv() (*proto.UploadBodyResponse, error)
+}
+
+type bodyDownloadClient interface {
+	Recv() (*proto.BodyChunk, error)
+}
+
+// chunkClient is the host side of streaming bodies in chunks: it uploads the bodies of the messages the host passes to
+// the plugin, and downloads the bodies of the messages the plugin returns.
+type chunkClient struct {
+	limits   Limits
+	upload   func(ctx context.Context) (bodyUploadClient, error)
+	download func(ctx context.Context, req *proto.DownloadBodyRequest) (bodyDownloadClient, error)
+
+	// describe describes the plugin, whose bodies are only streamed if it has CapabilityChunking
+	describe func(ctx context.Context) (*Description, error)
+}
+
+// chunking reports whether the plugin accepts streamed bodies. Plugins that don't get every body inline, which bounds
+// them by the maximum gRPC message size.
+func (c *chunkClient) chunking(ctx context.Context) (bool, error) {
+	d, err := c.describe(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	return d.Has(CapabilityChunking), nil
+}
+
+// toProto converts `messages`, uploading the bodies that don't fit in the call first.
+func (c *chunkClient) toProto(ctx context.Context, messages ...*Message) ([]*proto.AdapterMessage, error) {
+	budget := c.limits.chunkSize()
+	converted := make([]*proto.AdapterMessage, len(messages))
+
+	for i, message := range messages {
+		converted[i] = messageToProto(message)
+
+		if converted[i] == nil {
+			continue
+		}
+
+		if len(converted[i].Body) <= budget {
+			budget -= len(converted[i].Body)
+
+			continue
+		}
+
+		chunking, err := c.chunking(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !chunking {
+			continue
+		}
+
+		ref, err := c.uploadBody(ctx, converted[i].Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		converted[i].Body, converted[i].BodyRef = nil, ref
+	}
+
+	return converted, nil
+}
+
+func (c *chunkClient) uploadBody(ctx context.Context, body []byte) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.upload(ctx)
+
+	if err != nil {
+		return "", errorFromCall(ctx, err)
+	}
+
+	for size := c.limits.chunkSize(); len(body) > 0; {
+		if size > len(body) {
+			size = len(body)
+		}
+
+		// a failed Send means that the stream has ended; CloseAndRecv returns the reason
+		if err := stream.Send(&proto.BodyChunk{Data: body[:size]}); err != nil {
+			break
+		}
+
+		body = body[size:]
+	}
+
+	r, err := stream.CloseAndRecv()
+
+	if err != nil {
+		return "", errorFromCall(ctx, err)
+	}
+
+	return r.BodyRef, nil
+}
+
+// fromProto converts `message`, downloading its body if it has been streamed.
+func (c *chunkClient) fromProto(ctx context.Context, message *proto.AdapterMessage) (*Message, error) {
+	if err := checkBodySize(message, c.limits.maxBodySize()); err != nil {
+		return nil, err
+	}
+
+	m := messageFromProto(message)
+
+	if message == nil || message.BodyRef == "" {
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.download(ctx, &proto.DownloadBodyRequest{BodyRef: message.BodyRef})
+
+	if err != nil {
+		return nil, errorFromCall(ctx, err)
+	}
+
+	if m.Body, err = readChunks(stream.Recv, c.limits.maxBodySize()); err != nil {
+		return nil, errorFromCall(ctx, err)
+	}
+
+	return m, nil
+}
+
+func (c *chunkClient) taggedFromProto(ctx context.Context, message *proto.TaggedAdapterMessage) (*TaggedMessage, error) {
+	m, err := c.fromProto(ctx, message.Message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaggedMessage{
+		Tag:     message.Tag,
+		Message: m,
+	}, nil
+}
+
+// readChunks reads a body from a stream of chunks until it ends.
+func readChunks(recv func() (*proto.BodyChunk, error), max int) ([]byte, error) {
+	body := []byte{}
+
+	for {
+		chunk, err := recv()
+
+		if err == io.EOF {
+			return body, nil
+		}
+
+		if err != nil {
+			return nil, errorFromStatus(err)
+		}
+
+		if len(body)+len(chunk.Data) > max {
+			return nil, errBodyTooLarge(max)
+		}
+
+		body = append(body, chunk.Data...)
+	}
+}
+
+type bodyUploadServer interface {
+	Recv() (*proto.BodyChunk, error)
+	SendAndClose(*proto.UploadBodyResponse) error
+}
+
+type bodyDownloadServer interface {
+	Send(*proto.BodyChunk) error
+}
+
+// chunkServer is the plugin side of streaming bodies in chunks: it keeps the bodies that the host uploads until a
+// call refers to them, and the bodies of the messages it returns until the host downloads them.
+type chunkServer struct {
+	limits Limits
+
+	// hostChunking is 1 once the host has told that it downloads streamed bodies
+	hostChunking int32
+
+	mu     sync.Mutex
+	bodies map[string]storedBody
+}
+
+// bodyTTL is how long a streamed body is kept if nothing refers to it.
+const bodyTTL = 5 * time.Minute
+
+type storedBody struct {
+	data    []byte
+	expires time.Time
+}
+
+// accept records the capabilities of the host.
+func (s *chunkServer) accept(capabilities []string) {
+	for _, capability := range capabilities {
+		if Capability(capability) == CapabilityChunking {
+			atomic.StoreInt32(&s.hostChunking, 1)
+		}
+	}
+}
+
+func (s *chunkServer) put(body []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	for ref, stored := range s.bodies {
+		if now.After(stored.expires) {
+			delete(s.bodies, ref)
+		}
+	}
+
+	if s.bodies == nil {
+		s.bodies = make(map[string]storedBody)
+	}
+
+	ref := newMessageID()
+	s.bodies[ref] = storedBody{data: body, expires: now.Add(bodyTTL)}
+
+	return ref
+}
+
+func (s *chunkServer) take(ref string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.bodies[ref]
+
+	if !ok {
+		return nil, NewError("body_not_found", fmt.Sprintf("no streamed body %q", ref))
+	}
+
+	delete(s.bodies, ref)
+
+	return stored.data, nil
+}
+
+func (s *chunkServer) uploadBody(stream bodyUploadServer) error {
+	body, err := readChunks(stream.Recv, s.limits.maxBodySize())
+
+	if err != nil {
+		return errorToStatus(err)
+	}
+
+	return stream.SendAndClose(&proto.UploadBodyResponse{BodyRef: s.put(body)})
+}
+
+func (s *chunkServer) downloadBody(req *proto.DownloadBodyRequest, stream bodyDownloadServer) error {
+	body, err := s.take(req.BodyRef)
+
+	if err != nil {
+		return errorToStatus(err)
+	}
+
+	for size := s.limits.chunkSize(); len(body) > 0; body = body[size:] {
+		if size > len(body) {
+			size = len(body)
+		}
+
+		if err := stream.Send(&proto.BodyChunk{Data: body[:size]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toProto converts `messages`, keeping the bodies that don't fit in the call for the host to download if it supports
+// chunking.
+func (s *chunkServer) toProto(messages ...*Message) []*proto.AdapterMessage {
+	chunking := atomic.LoadInt32(&s.hostChunking) == 1
+	budget := s.limits.chunkSize()
+	converted := make([]*proto.AdapterMessage, len(messages))
+
+	for i, message := range messages {
+		converted[i] = messageToProto(message)
+
+		if converted[i] == nil || !chunking {
+			continue
+		}
+
+		if len(converted[i].Body) <= budget {
+			budget -= len(converted[i].Body)
+
+			continue
+		}
+
+		converted[i].Body, converted[i].BodyRef = nil, s.put(converted[i].Body)
+	}
+
+	return converted
+}
+
+func (s *chunkServer) taggedToProto(message *TaggedMessage) *proto.TaggedAdapterMessage {
+	return &proto.TaggedAdapterMessage{
+		Tag:     message.Tag,
+		Message: s.toProto(message.Message)[0],
+	}
+}
+
+// fromProto converts `message`, taking its body from the bodies uploaded by the host if it has been streamed.
+func (s *chunkServer) fromProto(message *proto.AdapterMessage) (*Message, error) {
+	if err := checkBodySize(message, s.limits.maxBodySize()); err != nil {
+		return nil, err
+	}
+
+	m := messageFromProto(message)
+
+	if message == nil || message.BodyRef == "" {
+		return m, nil
+	}
+
+	body, err := s.take(message.BodyRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.Body = body
+
+	return m, nil
+}
@@ -0,0 +1,122 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+var testLimits = Limits{ChunkSize: 8, MaxBodySize: 64}
+
+func TestGRPCEndpointLargeBodies(t *testing.T) {
+	large := bytes.Repeat([]byte("abcdef"), 7)
+
+	client, _ := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"endpoint": &EndpointPlugin{Impl: newQueueEndpoint(string(large)), Limits: testLimits},
+	})
+
+	raw, err := client.Dispense("endpoint")
+
+	if err != nil {
+		t.Fatalf("failed to dispense endpoint: %v", err)
+	}
+
+	endpoint := raw.(*GRPCEndpointClient)
+
+	if err := endpoint.Init(testStub{}, nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	response, err := endpoint.Send(testStub{}, NewMessage(large))
+
+	if err != nil || !bytes.Equal(response.Body, large) {
+		t.Fatalf("expected the large body to be sent and returned, got %v", err)
+	}
+
+	received, err := endpoint.Receive(testStub{})
+
+	if err != nil || !bytes.Equal(received.Message.Body, large) {
+		t.Fatalf("expected the large body to be received, got %v", err)
+	}
+
+	batch := []*Message{NewMessage([]byte("abcde")), NewMessage([]byte("fghij")), NewMessage(large)}
+	results, err := SendBatch(context.Background(), endpoint, testStub{}, batch)
+
+	if err != nil {
+		t.Fatalf("failed to send batch: %v", err)
+	}
+
+	for i, result := range results {
+		if result.Err != nil || !bytes.Equal(result.Response.Body, batch[i].Body) {
+			t.Fatalf("expected message %d of the batch to be returned, got %+v", i, result)
+		}
+	}
+
+	_, err = endpoint.Send(testStub{}, NewMessage(bytes.Repeat(large, 2)))
+
+	if e, ok := AsError(err); !ok || e.Code != "body_too_large" || !IsPermanent(err) {
+		t.Fatalf("expected a body over the maximum to fail with body_too_large, got %v", err)
+	}
+}
+
+func TestGRPCActionLargeBodies(t *testing.T) {
+	client, _ := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"action": &ActionPlugin{Impl: &suffixAction{}, Limits: testLimits},
+	})
+
+	raw, err := client.Dispense("action")
+
+	if err != nil {
+		t.Fatalf("failed to dispense action: %v", err)
+	}
+
+	action := raw.(*GRPCActionClient)
+	defer action.Close()
+
+	if err := action.Init(testStub{}, []byte("!")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	large := bytes.Repeat([]byte("abcdef"), 7)
+	message := NewMessage(large)
+
+	if err := action.Invoke(testStub{}, message); err != nil || string(message.Body) != string(large)+"!" {
+		t.Fatalf("expected the large body to be invoked, got %q, %v", message.Body, err)
+	}
+
+	messages, err := action.InvokeMulti(testStub{}, NewMessage(large))
+
+	if err != nil || len(messages) != 1 || string(messages[0].Body) != string(large)+"!" {
+		t.Fatalf("expected the large body to be invoked, got %v, %v", messages, err)
+	}
+
+	batch := []*Message{NewMessage(large), NewMessage([]byte("a"))}
+	errs, err := action.InvokeBatch(testStub{}, batch)
+
+	if err != nil || errs[0] != nil || errs[1] != nil || string(batch[0].Body) != string(large)+"!" || string(batch[1].Body) != "a!" {
+		t.Fatalf("expected the batch to be invoked, got %v, %v", errs, err)
+	}
+}
+
+func TestChunkServerHostChunking(t *testing.T) {
+	s := &chunkServer{limits: testLimits}
+	large := bytes.Repeat([]byte("abcdef"), 7)
+
+	if converted := s.toProto(NewMessage(large))[0]; converted.BodyRef != "" || !bytes.Equal(converted.Body, large) {
+		t.Fatalf("expected the body to be inline until the host supports chunking, got %+v", converted)
+	}
+
+	s.accept(hostCapabilities)
+
+	if converted := s.toProto(NewMessage(large))[0]; converted.BodyRef == "" || converted.Body != nil {
+		t.Fatalf("expected the body to be streamed once the host supports chunking, got %+v", converted)
+	}
+
+	_, err := s.fromProto(messageToProto(NewMessage(bytes.Repeat(large, 2))))
+
+	if e, ok := AsError(err); !ok || e.Code != "body_too_large" {
+		t.Fatalf("expected an inline body over the maximum to fail with body_too_large, got %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package adapter
+
+import "context"
+
+// NewContextEndpoint returns `endpoint` itself if it already implements ContextEndpoint. Otherwise the returned
+// ContextEndpoint runs every call in its own goroutine and returns ctx.Err() as soon as `ctx` is done. The abandoned
+// call keeps running in the background; a message that is received after its Receive call has been abandoned is
+// Nacked, so that the endpoint can redeliver it.
+//
+// Calls are not serialized, so the next call can start while an abandoned one is still running: `endpoint` must
+// tolerate overlapping calls, including two Receive or two Send calls at once.
+func NewContextEndpoint(endpoint Endpoint) ContextEndpoint {
+	if ce, ok := endpoint.(ContextEndpoint); ok {
+		return ce
+	}
+
+	return &contextEndpoint{Endpoint: endpoint}
+}
+
+type contextEndpoint struct {
+	Endpoint
+}
+
+func (e *contextEndpoint) InitContext(ctx context.Context, stub Stub, config []byte) error {
+	return runContext(ctx, func() error {
+		return e.Init(stub, config)
+	})
+}
+
+func (e *contextEndpoint) SendContext(ctx context.Context, stub Stub, message *Message) (*Message, error) {
+	if ctx.Done() == nil {
+		return e.Send(stub, message)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		response *Message
+		err      error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		response, err := e.Send(stub, message)
+		done <- result{response: response, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.response, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (e *contextEndpoint) ReceiveContext(ctx context.Context, stub Stub) (*TaggedMessage, error) {
+	if ctx.Done() == nil {
+		return e.Receive(stub)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		message *TaggedMessage
+		err     error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		message, err := e.Receive(stub)
+		done <- result{message: message, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.message, r.err
+	case <-ctx.Done():
+		err := ctx.Err()
+
+		go func() {
+			if r := <-done; r.err == nil {
+				e.Nack(stub, r.message.Tag, err)
+			}
+		}()
+
+		return nil, err
+	}
+}
+
+func (e *contextEndpoint) AckContext(ctx context.Context, stub Stub, tag uint64, response *Message) error {
+	return runContext(ctx, func() error {
+		return e.Ack(stub, tag, response)
+	})
+}
+
+func (e *contextEndpoint) NackContext(ctx context.Context, stub Stub, tag uint64, err error) error {
+	return runContext(ctx, func() error {
+		return e.Nack(stub, tag, err)
+	})
+}
+
+func (e *contextEndpoint) CloseContext(ctx context.Context, stub Stub) error {
+	return runContext(ctx, func() error {
+		return e.Close(stub)
+	})
+}
+
+// BackgroundEndpoint turns a ContextEndpoint into an Endpoint by calling it with context.Background(). The result still
+// implements ContextEndpoint (and ContextStreamingEndpoint and StreamingEndpoint, if `endpoint` is streaming), so it
+// can be passed to StartEndpoint without losing cancellation support.
+func BackgroundEndpoint(endpoint ContextEndpoint) Endpoint {
+	if streaming, ok := endpoint.(ContextStreamingEndpoint); ok {
+		return &backgroundStreamingEndpoint{
+			backgroundEndpoint:       backgroundEndpoint{ContextEndpoint: streaming},
+			ContextStreamingEndpoint: streaming,
+		}
+	}
+
+	return &backgroundEndpoint{ContextEndpoint: endpoint}
+}
+
+type backgroundEndpoint struct {
+	ContextEndpoint
+}
+
+func (e *backgroundEndpoint) Init(stub Stub, config []byte) error {
+	return e.InitContext(context.Background(), stub, config)
+}
+
+func (e *backgroundEndpoint) Send(stub Stub, message *Message) (*Message, error) {
+	return e.SendContext(context.Background(), stub, message)
+}
+
+func (e *backgroundEndpoint) Receive(stub Stub) (*TaggedMessage, error) {
+	return e.ReceiveContext(context.Background(), stub)
+}
+
+func (e *backgroundEndpoint) Ack(stub Stub, tag uint64, response *Message) error {
+	return e.AckContext(context.Background(), stub, tag, response)
+}
+
+func (e *backgroundEndpoint) Nack(stub Stub, tag uint64, err error) error {
+	return e.NackContext(context.Background(), stub, tag, err)
+}
+
+func (e *backgroundEndpoint) Close(stub Stub) error {
+	return e.CloseContext(context.Background(), stub)
+}
+
+type backgroundStreamingEndpoint struct {
+	backgroundEndpoint
+	ContextStreamingEndpoint
+}
+
+func (e *backgroundStreamingEndpoint) ReceiveStream(stub Stub, messages chan<- *TaggedMessage) error {
+	return e.ReceiveStreamContext(context.Background(), stub, messages)
+}
+
+// NewContextAction returns `action` itself if it already implements ContextAction. Otherwise the returned ContextAction
+// runs every call in its own goroutine and returns ctx.Err() as soon as `ctx` is done. An abandoned Invoke works on a
+// copy of the message, so it never modifies the message after InvokeContext has returned.
+//
+// Calls are not serialized, so the next Invoke can start while an abandoned one is still running: `action` must
+// tolerate overlapping calls.
+func NewContextAction(action Action) ContextAction {
+	if ca, ok := action.(ContextAction); ok {
+		return ca
+	}
+
+	return &contextAction{Action: action}
+}
+
+type contextAction struct {
+	Action
+}
+
+func (a *contextAction) InitContext(ctx context.Context, stub Stub, config []byte) error {
+	return runContext(ctx, func() error {
+		return a.Init(stub, config)
+	})
+}
+
+func (a *contextAction) InvokeContext(ctx context.Context, stub Stub, message *Message) error {
+	if ctx.Done() == nil {
+		return a.Invoke(stub, message)
+	}
+
+	invoked := cloneMessage(message)
+
+	err := runContext(ctx, func() error {
+		return a.Invoke(stub, invoked)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	*message = *invoked
+
+	return nil
+}
+
+// BackgroundAction turns a ContextAction into an Action by calling it with context.Background(). The result still
+// implements ContextAction, so it can be passed to StartAction without losing cancellation support.
+func BackgroundAction(action ContextAction) Action {
+	return &backgroundAction{ContextAction: action}
+}
+
+type backgroundAction struct {
+	ContextAction
+}
+
+func (a *backgroundAction) Init(stub Stub, config []byte) error {
+	return a.InitContext(context.Background(), stub, config)
+}
+
+func (a *backgroundAction) Invoke(stub Stub, message *Message) error {
+	return a.InvokeContext(context.Background(), stub, message)
+}
+
+// runContext runs `fn` and returns its error, or ctx.Err() if `ctx` is done first.
+func runContext(ctx context.Context, fn func() error) error {
+	if ctx.Done() == nil {
+		return fn()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
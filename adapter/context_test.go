@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// blockingEndpoint blocks in ReceiveContext until its context is done and reports whether that context had a deadline.
+type blockingEndpoint struct {
+	queueEndpoint
+	deadline chan bool
+}
+
+func (e *blockingEndpoint) InitContext(ctx context.Context, stub Stub, config []byte) error {
+	return nil
+}
+
+func (e *blockingEndpoint) SendContext(ctx context.Context, stub Stub, message *Message) (*Message, error) {
+	return message, nil
+}
+
+func (e *blockingEndpoint) ReceiveContext(ctx context.Context, stub Stub) (*TaggedMessage, error) {
+	<-ctx.Done()
+
+	_, ok := ctx.Deadline()
+	e.deadline <- ok
+
+	return nil, ctx.Err()
+}
+
+func (e *blockingEndpoint) AckContext(ctx context.Context, stub Stub, tag uint64, response *Message) error {
+	return nil
+}
+
+func (e *blockingEndpoint) NackContext(ctx context.Context, stub Stub, tag uint64, err error) error {
+	return nil
+}
+
+func (e *blockingEndpoint) CloseContext(ctx context.Context, stub Stub) error { return nil }
+
+func TestGRPCEndpointReceiveContextDeadline(t *testing.T) {
+	impl := &blockingEndpoint{deadline: make(chan bool, 1)}
+	endpoint := dispenseEndpoint(t, impl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := endpoint.ReceiveContext(ctx, testStub{})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	select {
+	case ok := <-impl.deadline:
+		if !ok {
+			t.Fatalf("expected the plugin context to carry the deadline")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the plugin context to be done")
+	}
+}
+
+func TestNewContextEndpointCancelsReceive(t *testing.T) {
+	// an unbuffered, never closed queue makes Receive block forever
+	endpoint := NewContextEndpoint(&queueEndpoint{queue: make(chan *TaggedMessage)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := endpoint.ReceiveContext(ctx, testStub{}); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := endpoint.ReceiveContext(ctx, testStub{}); err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,245 @@
+package adapter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProtocolVersion is the version of the adapter protocol that this package speaks, and LegacyProtocolVersion the
+// version of the plugins that predate Describe.
+//
+// EndpointHandshake and ActionHandshake stay at LegacyProtocolVersion, so that hosts and plugins of either version
+// can load each other. The clients describe the plugin once, and never call the RPCs that its protocol version lacks.
+const (
+	ProtocolVersion       = 3
+	LegacyProtocolVersion = 2
+)
+
+// Capability is an optional feature of a plugin.
+type Capability string
+
+const (
+	// CapabilityContext: the plugin honours the deadline and cancellation of calls, instead of having them abandoned
+	// on its side
+	CapabilityContext Capability = "context"
+
+	// CapabilityStreaming: the endpoint streams received messages instead of being polled with Receive
+	CapabilityStreaming Capability = "streaming"
+
+	// CapabilityBatch: the plugin sends or invokes batches in a single call
+	CapabilityBatch Capability = "batch"
+
+	// CapabilityMulti: the action can drop messages or turn them into several
+	CapabilityMulti Capability = "multi"
+
+	// CapabilityChunking: the plugin accepts and returns bodies that are streamed in chunks
+	CapabilityChunking Capability = "chunking"
+
+	// CapabilityKV: the plugin reaches the KV and Secrets of the host through its stub
+	CapabilityKV Capability = "kv"
+
+	// CapabilityMetrics: the plugin reports metrics to the host through its stub
+	CapabilityMetrics Capability = "metrics"
+)
+
+// Description tells which version of the adapter protocol a plugin speaks, and which capabilities it has.
+type Description struct {
+	ProtocolVersion uint
+	Capabilities    []Capability
+
+	// Manifest is nil for plugins that predate manifests
+	Manifest *Manifest
+}
+
+// Has reports whether the plugin has capability `c`.
+func (d *Description) Has(c Capability) bool {
+	for _, capability := range d.Capabilities {
+		if capability == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Protocol returns the best protocol version that both this package and the plugin speak.
+func (d *Description) Protocol() uint {
+	if d.ProtocolVersion < ProtocolVersion {
+		return d.ProtocolVersion
+	}
+
+	return ProtocolVersion
+}
+
+// DescribeEndpoint describes `endpoint` when it is served by this package.
+func DescribeEndpoint(endpoint Endpoint) *Description {
+	d := &Description{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    []Capability{CapabilityChunking, CapabilityKV, CapabilityMetrics},
+	}
+
+	if _, ok := endpoint.(ContextEndpoint); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityContext)
+	}
+
+	if _, ok := endpoint.(StreamingEndpoint); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityStreaming)
+	} else if _, ok := endpoint.(ContextStreamingEndpoint); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityStreaming)
+	}
+
+	if _, ok := endpoint.(BatchEndpoint); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityBatch)
+	} else if _, ok := endpoint.(ContextBatchEndpoint); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityBatch)
+	}
+
+	return d
+}
+
+// DescribeAction describes `action` when it is served by this package.
+func DescribeAction(action Action) *Description {
+	d := &Description{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    []Capability{CapabilityChunking, CapabilityKV, CapabilityMetrics},
+	}
+
+	if _, ok := action.(ContextAction); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityContext)
+	}
+
+	if _, ok := action.(BatchAction); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityBatch)
+	} else if _, ok := action.(ContextBatchAction); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityBatch)
+	}
+
+	if _, ok := action.(MultiAction); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityMulti)
+	} else if _, ok := action.(ContextMultiAction); ok {
+		d.Capabilities = append(d.Capabilities, CapabilityMulti)
+	}
+
+	return d
+}
+
+func describeToProto(d *Description) *proto.DescribeResponse {
+	r := &proto.DescribeResponse{
+		ProtocolVersion: uint32(d.ProtocolVersion),
+	}
+
+	for _, capability := range d.Capabilities {
+		r.Capabilities = append(r.Capabilities, string(capability))
+	}
+
+	if d.Manifest != nil {
+		r.Manifest = manifestToProto(*d.Manifest)
+	}
+
+	return r
+}
+
+// describeFromProto converts the outcome of a Describe call. Plugins that don't implement it speak
+// LegacyProtocolVersion, and have none of the capabilities.
+func describeFromProto(r *proto.DescribeResponse, err error) (*Description, error) {
+	if status.Code(err) == codes.Unimplemented {
+		return &Description{ProtocolVersion: LegacyProtocolVersion}, nil
+	}
+
+	if err != nil {
+		return nil, errorFromStatus(err)
+	}
+
+	d := &Description{
+		ProtocolVersion: uint(r.ProtocolVersion),
+	}
+
+	for _, capability := range r.Capabilities {
+		d.Capabilities = append(d.Capabilities, Capability(capability))
+	}
+
+	d.Manifest = manifestFromProto(r.Manifest, d.Capabilities)
+
+	return d, nil
+}
+
+// descriptionCache keeps the description of a plugin, which the clients ask for once.
+type descriptionCache struct {
+	mu          sync.Mutex
+	description *Description
+}
+
+// get returns the cached description, calling `describe` if there is none yet. Failed calls are not cached.
+func (c *descriptionCache) get(describe func() (*Description, error)) (*Description, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.description != nil {
+		return c.description, nil
+	}
+
+	d, err := describe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.description = d
+
+	return d, nil
+}
+
+// isLegacy reports whether the plugin described by `d` speaks LegacyProtocolVersion, and lacks the RPCs added since.
+func isLegacy(d *Description, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+
+	return d.Protocol() < ProtocolVersion, nil
+}
+
+// Describe asks the plugin which protocol version it speaks, which capabilities it has and for its manifest. The
+// plugin is only asked once; later calls return the same description.
+func (m *GRPCEndpointClient) Describe(ctx context.Context) (*Description, error) {
+	return m.description.get(func() (*Description, error) {
+		return describeFromProto(m.client.Describe(ctx, &proto.DescribeRequest{HostCapabilities: hostCapabilities}))
+	})
+}
+
+func (m *GRPCEndpointServer) Describe(ctx context.Context, req *proto.DescribeRequest) (*proto.DescribeResponse, error) {
+	m.bodies.accept(req.HostCapabilities)
+
+	d := DescribeEndpoint(m.Impl)
+
+	manifest := m.manifest
+	manifest.Kind = KindEndpoint
+	manifest.Capabilities = d.Capabilities
+	d.Manifest = &manifest
+
+	return describeToProto(d), nil
+}
+
+// Describe asks the plugin which protocol version it speaks, which capabilities it has and for its manifest. The
+// plugin is only asked once; later calls return the same description.
+func (m *GRPCActionClient) Describe(ctx context.Context) (*Description, error) {
+	return m.description.get(func() (*Description, error) {
+		return describeFromProto(m.client.Describe(ctx, &proto.DescribeRequest{HostCapabilities: hostCapabilities}))
+	})
+}
+
+func (m *GRPCActionServer) Describe(ctx context.Context, req *proto.DescribeRequest) (*proto.DescribeResponse, error) {
+	m.bodies.accept(req.HostCapabilities)
+
+	d := DescribeAction(m.Impl)
+
+	manifest := m.manifest
+	manifest.Kind = KindAction
+	manifest.Capabilities = d.Capabilities
+	d.Manifest = &manifest
+
+	return describeToProto(d), nil
+}
@@ -0,0 +1,120 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// legacyEndpointClient is the client of a plugin that predates Describe and the RPCs added with it. It counts the
+// calls of those RPCs.
+type legacyEndpointClient struct {
+	proto.EndpointClient
+	calls *int32
+}
+
+func (c legacyEndpointClient) unimplemented(method string) error {
+	atomic.AddInt32(c.calls, 1)
+
+	return status.Error(codes.Unimplemented, "unknown method "+method)
+}
+
+func (c legacyEndpointClient) Describe(ctx context.Context, in *proto.DescribeRequest, opts ...grpc.CallOption) (*proto.DescribeResponse, error) {
+	return nil, c.unimplemented("Describe")
+}
+
+func (c legacyEndpointClient) SendBatch(ctx context.Context, in *proto.SendBatchRequest, opts ...grpc.CallOption) (*proto.SendBatchResponse, error) {
+	return nil, c.unimplemented("SendBatch")
+}
+
+func (c legacyEndpointClient) ReceiveStream(ctx context.Context, in *proto.ReceiveRequest, opts ...grpc.CallOption) (proto.Endpoint_ReceiveStreamClient, error) {
+	return nil, c.unimplemented("ReceiveStream")
+}
+
+func (c legacyEndpointClient) UploadBody(ctx context.Context, opts ...grpc.CallOption) (proto.Endpoint_UploadBodyClient, error) {
+	return nil, c.unimplemented("UploadBody")
+}
+
+// dispenseLegacyEndpoint dispenses `impl` as a plugin that predates Describe, and returns the number of calls of the
+// RPCs that such a plugin lacks.
+func dispenseLegacyEndpoint(t testing.TB, impl Endpoint) (*GRPCEndpointClient, *int32) {
+	endpoint := dispenseEndpoint(t, impl)
+	calls := new(int32)
+	endpoint.client = legacyEndpointClient{EndpointClient: endpoint.client, calls: calls}
+
+	return endpoint, calls
+}
+
+func TestGRPCEndpointDescribe(t *testing.T) {
+	endpoint := dispenseEndpoint(t, &batchEndpoint{})
+
+	d, err := endpoint.Describe(context.Background())
+
+	if err != nil {
+		t.Fatalf("failed to describe endpoint: %v", err)
+	}
+
+	if d.Protocol() != ProtocolVersion || !d.Has(CapabilityBatch) || !d.Has(CapabilityChunking) || d.Has(CapabilityStreaming) {
+		t.Fatalf("expected a batch endpoint of protocol version %d, got %+v", ProtocolVersion, d)
+	}
+
+	if again, _ := endpoint.Describe(context.Background()); again != d {
+		t.Fatalf("expected the description to be cached")
+	}
+
+	legacy, _ := dispenseLegacyEndpoint(t, &batchEndpoint{})
+
+	d, err = legacy.Describe(context.Background())
+
+	if err != nil || d.Protocol() != LegacyProtocolVersion || len(d.Capabilities) != 0 {
+		t.Fatalf("expected a legacy plugin to be described by its protocol version only, got %+v, %v", d, err)
+	}
+}
+
+func TestGRPCEndpointLegacyPlugin(t *testing.T) {
+	large := bytes.Repeat([]byte("a"), 2*DefaultChunkSize)
+	endpoint, calls := dispenseLegacyEndpoint(t, newQueueEndpoint("a", "b"))
+
+	for i := 0; i < 2; i++ {
+		results, err := endpoint.SendBatch(testStub{}, []*Message{NewMessage([]byte("a")), NewMessage(large)})
+
+		if err != nil || len(results) != 2 || results[1].Err != nil || !bytes.Equal(results[1].Response.Body, large) {
+			t.Fatalf("expected the batch to be sent message by message, got %+v, %v", results, err)
+		}
+	}
+
+	messages := make(chan *TaggedMessage, 2)
+
+	if err := endpoint.ReceiveStream(testStub{}, messages); err == nil || len(messages) != 2 {
+		t.Fatalf("expected the messages to be received one by one until the queue was drained, got %d, %v", len(messages), err)
+	}
+
+	if *calls != 1 {
+		t.Fatalf("expected only Describe to be called among the RPCs that the plugin lacks, got %d calls", *calls)
+	}
+}
+
+func TestGRPCActionDescribe(t *testing.T) {
+	action := dispenseAction(t, &dropAction{})
+	defer action.Close()
+
+	d, err := action.Describe(context.Background())
+
+	if err != nil || !d.Has(CapabilityMulti) || d.Has(CapabilityBatch) {
+		t.Fatalf("expected a multi action, got %+v, %v", d, err)
+	}
+}
+
+func TestDescriptionProtocol(t *testing.T) {
+	d := &Description{ProtocolVersion: ProtocolVersion + 1}
+
+	if d.Protocol() != ProtocolVersion {
+		t.Fatalf("expected a newer plugin to speak protocol version %d, got %d", ProtocolVersion, d.Protocol())
+	}
+}
@@ -1,15 +1,33 @@
 package adapter
 
-import "github.com/hashicorp/go-plugin"
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+func StartEndpoint(endpoint Endpoint, opts ...StartOption) {
+	c := newStartConfig(opts)
+
+	if describeRequested() {
+		if err := writeManifest(os.Stdout, c.manifest, KindEndpoint, DescribeEndpoint(endpoint)); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to describe the plugin: %v\n", err)
+			os.Exit(1)
+		}
+
+		return
+	}
 
-func StartEndpoint(endpoint Endpoint) {
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: EndpointHandshake,
 		Plugins: map[string]plugin.Plugin{
-			"endpoint": &EndpointPlugin{Impl: endpoint},
+			"endpoint": &EndpointPlugin{Impl: endpoint, Limits: c.limits, Manifest: c.manifest},
 		},
 
 		// A non-nil value here enables gRPC serving for this plugin...
 		GRPCServer: plugin.DefaultGRPCServer,
 	})
+
+	pipelines.flush()
 }
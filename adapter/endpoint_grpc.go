@@ -1,220 +1,481 @@
 package adapter
 
 import (
-	"errors"
+	"fmt"
+	"io"
 
-	"github.com/hashicorp/go-plugin"
 	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
 	"golang.org/x/net/context"
 )
 
 // GRPCClient is an implementation of KV that talks over RPC.
 type GRPCEndpointClient struct {
-	broker *plugin.GRPCBroker
+	broker Broker
 	client proto.EndpointClient
+	stubs  persistentStubServer
+	bodies chunkClient
+
+	description descriptionCache
+}
+
+// Unhealthy returns the *FatalError of the first Fatalf or Panicf call of the plugin, and nil if it made none.
+func (m *GRPCEndpointClient) Unhealthy() error {
+	return m.stubs.health.unhealthy()
+}
+
+// SetLogLevel sets the lowest level that the plugin logs, overriding the level of the logger of its stub. The plugin
+// drops the records below it without sending them to the host.
+func (m *GRPCEndpointClient) SetLogLevel(level logger.Level) {
+	m.stubs.level.update(level)
 }
 
 func (m *GRPCEndpointClient) Init(stub Stub, cfg []byte) error {
-	brokerID, closer := SetupStubServer(stub, m.broker)
-	defer closer()
+	return m.InitContext(context.Background(), stub, cfg)
+}
 
-	_, err := m.client.Init(context.Background(), &proto.InitEndpointRequest{
-		StubServer: brokerID,
-		Config:     cfg,
+func (m *GRPCEndpointClient) InitContext(ctx context.Context, stub Stub, cfg []byte) error {
+	_, err := m.client.Init(ctx, &proto.InitEndpointRequest{
+		StubServer:       m.stubs.open(m.broker, stub),
+		Config:           cfg,
+		HostCapabilities: hostCapabilities,
 	})
 
-	return err
+	if err != nil {
+		m.stubs.close()
+	}
+
+	return errorFromCall(ctx, err)
 }
 
 func (m *GRPCEndpointClient) Send(stub Stub, message *Message) (*Message, error) {
-	brokerID, closer := SetupStubServer(stub, m.broker)
+	return m.SendContext(context.Background(), stub, message)
+}
+
+func (m *GRPCEndpointClient) SendContext(ctx context.Context, stub Stub, message *Message) (*Message, error) {
+	brokerID, closer := m.stubs.get(m.broker, stub)
 	defer closer()
 
-	r, err := m.client.Send(context.Background(), &proto.SendRequest{
+	messages, err := m.bodies.toProto(ctx, message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := m.client.Send(ctx, &proto.SendRequest{
 		StubServer: brokerID,
-		Message: &proto.AdapterMessage{
-			Body:       message.Body,
-			Attributes: message.Attributes,
-		},
+		Message:    messages[0],
 	})
 
+	if err != nil {
+		return nil, errorFromCall(ctx, err)
+	}
+
+	return m.bodies.fromProto(ctx, r.Response)
+}
+
+func (m *GRPCEndpointClient) SendBatch(stub Stub, messages []*Message) ([]BatchResult, error) {
+	return m.SendBatchContext(context.Background(), stub, messages)
+}
+
+// SendBatchContext sends all messages in a single call. Plugins that predate SendBatch get one Send per message.
+func (m *GRPCEndpointClient) SendBatchContext(ctx context.Context, stub Stub, messages []*Message) ([]BatchResult, error) {
+	legacy, err := isLegacy(m.Describe(ctx))
+
 	if err != nil {
 		return nil, err
 	}
 
-	return &Message{
-		Body:       r.Response.Body,
-		Attributes: r.Response.Attributes,
-	}, nil
+	if legacy {
+		return sendEach(ctx, m, stub, messages), nil
+	}
+
+	brokerID, closer := m.stubs.get(m.broker, stub)
+	defer closer()
+
+	converted, err := m.bodies.toProto(ctx, messages...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := m.client.SendBatch(ctx, &proto.SendBatchRequest{
+		StubServer: brokerID,
+		Messages:   converted,
+	})
+
+	if err != nil {
+		return nil, errorFromCall(ctx, err)
+	}
+
+	if len(r.Results) != len(messages) {
+		return nil, fmt.Errorf("adapter: SendBatch returned %d results for %d messages", len(r.Results), len(messages))
+	}
+
+	results := make([]BatchResult, len(r.Results))
+
+	for i, item := range r.Results {
+		results[i].Err = batchItemError(item)
+
+		if results[i].Response, err = m.bodies.fromProto(ctx, item.GetMessage()); err != nil && results[i].Err == nil {
+			results[i].Err = err
+		}
+	}
+
+	return results, nil
 }
 
 func (m *GRPCEndpointClient) Receive(stub Stub) (*TaggedMessage, error) {
-	brokerID, closer := SetupStubServer(stub, m.broker)
+	return m.ReceiveContext(context.Background(), stub)
+}
+
+func (m *GRPCEndpointClient) ReceiveContext(ctx context.Context, stub Stub) (*TaggedMessage, error) {
+	brokerID, closer := m.stubs.get(m.broker, stub)
 	defer closer()
 
-	r, err := m.client.Receive(context.Background(), &proto.ReceiveRequest{
+	r, err := m.client.Receive(ctx, &proto.ReceiveRequest{
 		StubServer: brokerID,
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, errorFromCall(ctx, err)
 	}
 
-	return &TaggedMessage{
-		Tag: r.Message.Tag,
-		Message: &Message{
-			Body:       r.Message.Message.Body,
-			Attributes: r.Message.Message.Attributes,
-		},
-	}, nil
+	return m.bodies.taggedFromProto(ctx, r.Message)
+}
+
+func (m *GRPCEndpointClient) ReceiveStream(stub Stub, messages chan<- *TaggedMessage) error {
+	return m.ReceiveStreamContext(context.Background(), stub, messages)
+}
+
+// ReceiveStreamContext streams the messages that the plugin receives. Plugins that predate ReceiveStream get one
+// Receive per message.
+func (m *GRPCEndpointClient) ReceiveStreamContext(ctx context.Context, stub Stub, messages chan<- *TaggedMessage) error {
+	legacy, err := isLegacy(m.Describe(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	if legacy {
+		return receiveEach(ctx, m, stub, messages)
+	}
+
+	brokerID, closer := m.stubs.get(m.broker, stub)
+	defer closer()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := m.client.ReceiveStream(ctx, &proto.ReceiveRequest{
+		StubServer: brokerID,
+	})
+
+	if err != nil {
+		return errorFromCall(ctx, err)
+	}
+
+	for {
+		r, err := stream.Recv()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return errorFromCall(ctx, err)
+		}
+
+		message, err := m.bodies.taggedFromProto(ctx, r.Message)
+
+		if err != nil {
+			m.NackContext(context.Background(), stub, r.Message.GetTag(), err)
+
+			return err
+		}
+
+		select {
+		case messages <- message:
+		case <-ctx.Done():
+			m.NackContext(context.Background(), stub, message.Tag, ctx.Err())
+
+			return ctx.Err()
+		}
+	}
 }
 
 func (m *GRPCEndpointClient) Ack(stub Stub, tag uint64, response *Message) error {
-	brokerID, closer := SetupStubServer(stub, m.broker)
+	return m.AckContext(context.Background(), stub, tag, response)
+}
+
+func (m *GRPCEndpointClient) AckContext(ctx context.Context, stub Stub, tag uint64, response *Message) error {
+	brokerID, closer := m.stubs.get(m.broker, stub)
 	defer closer()
 
-	_, err := m.client.Ack(context.Background(), &proto.AckRequest{
+	responses, err := m.bodies.toProto(ctx, response)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Ack(ctx, &proto.AckRequest{
 		StubServer: brokerID,
 		Tag:        tag,
-		Response: &proto.AdapterMessage{
-			Body:       response.Body,
-			Attributes: response.Attributes,
-		},
+		Response:   responses[0],
 	})
 
-	return err
+	return errorFromCall(ctx, err)
 }
 
 func (m *GRPCEndpointClient) Nack(stub Stub, tag uint64, responseError error) error {
-	brokerID, closer := SetupStubServer(stub, m.broker)
+	return m.NackContext(context.Background(), stub, tag, responseError)
+}
+
+func (m *GRPCEndpointClient) NackContext(ctx context.Context, stub Stub, tag uint64, responseError error) error {
+	brokerID, closer := m.stubs.get(m.broker, stub)
 	defer closer()
 
-	_, err := m.client.Nack(context.Background(), &proto.NackRequest{
-		StubServer: brokerID,
-		Tag:        tag,
-		Error:      responseError.Error(),
+	_, err := m.client.Nack(ctx, &proto.NackRequest{
+		StubServer:  brokerID,
+		Tag:         tag,
+		Error:       responseError.Error(),
+		ErrorDetail: errorToProto(responseError),
 	})
 
-	return err
+	return errorFromCall(ctx, err)
 }
 
 func (m *GRPCEndpointClient) Close(stub Stub) error {
-	brokerID, closer := SetupStubServer(stub, m.broker)
+	return m.CloseContext(context.Background(), stub)
+}
+
+func (m *GRPCEndpointClient) CloseContext(ctx context.Context, stub Stub) error {
+	brokerID, closer := m.stubs.get(m.broker, stub)
 	defer closer()
+	defer m.stubs.close()
 
-	_, err := m.client.Close(context.Background(), &proto.CloseRequest{
+	_, err := m.client.Close(ctx, &proto.CloseRequest{
 		StubServer: brokerID,
 	})
 
-	return err
+	return errorFromCall(ctx, err)
 }
 
 // Here is the gRPC server that GRPCClient talks to.
 type GRPCEndpointServer struct {
 	// This is the real implementation
-	Impl   Endpoint
-	broker *plugin.GRPCBroker
+	Impl     Endpoint
+	broker   Broker
+	stubs    persistentStubClient
+	bodies   chunkServer
+	manifest Manifest
 }
 
 func (m *GRPCEndpointServer) Init(ctx context.Context, req *proto.InitEndpointRequest) (*proto.InitEndpointResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	m.bodies.accept(req.HostCapabilities)
+
+	stub, err := m.stubs.open(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
-	defer closer()
+	err = NewContextEndpoint(m.Impl).InitContext(ctx, stub, req.Config)
+
+	if err != nil {
+		m.stubs.disconnect()
+	} else {
+		m.stubs.flush()
+	}
 
-	return &proto.InitEndpointResponse{}, m.Impl.Init(stub, req.Config)
+	return &proto.InitEndpointResponse{}, errorToStatus(err)
 }
 
 func (m *GRPCEndpointServer) Send(ctx context.Context, req *proto.SendRequest) (*proto.SendResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	defer closer()
 
-	r, err := m.Impl.Send(stub, &Message{
-		Body:       req.Message.Body,
-		Attributes: req.Message.Attributes,
-	})
+	message, err := m.bodies.fromProto(req.Message)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
+	}
+
+	r, err := NewContextEndpoint(m.Impl).SendContext(ctx, stub, message)
+
+	if err != nil {
+		return nil, errorToStatus(err)
 	}
 
 	return &proto.SendResponse{
-		Response: &proto.AdapterMessage{
-			Body:       r.Body,
-			Attributes: r.Attributes,
-		},
+		Response: m.bodies.toProto(r)[0],
 	}, nil
 }
 
+func (m *GRPCEndpointServer) SendBatch(ctx context.Context, req *proto.SendBatchRequest) (*proto.SendBatchResponse, error) {
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	defer closer()
+
+	messages := make([]*Message, len(req.Messages))
+
+	for i, message := range req.Messages {
+		if messages[i], err = m.bodies.fromProto(message); err != nil {
+			return nil, errorToStatus(err)
+		}
+	}
+
+	results, err := SendBatch(ctx, m.Impl, stub, messages)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	responses := make([]*Message, len(results))
+
+	for i, result := range results {
+		responses[i] = result.Response
+	}
+
+	r := &proto.SendBatchResponse{}
+
+	for i, response := range m.bodies.toProto(responses...) {
+		r.Results = append(r.Results, batchItemToProto(response, results[i].Err))
+	}
+
+	return r, nil
+}
+
 func (m *GRPCEndpointServer) Receive(ctx context.Context, req *proto.ReceiveRequest) (*proto.ReceiveResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	defer closer()
 
-	r, err := m.Impl.Receive(stub)
+	r, err := NewContextEndpoint(m.Impl).ReceiveContext(ctx, stub)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	return &proto.ReceiveResponse{
-		Message: &proto.TaggedAdapterMessage{
-			Tag: r.Tag,
-			Message: &proto.AdapterMessage{
-				Body:       r.Body,
-				Attributes: r.Attributes,
-			},
-		},
+		Message: m.bodies.taggedToProto(r),
 	}, nil
 }
 
+func (m *GRPCEndpointServer) ReceiveStream(req *proto.ReceiveRequest, srv proto.Endpoint_ReceiveStreamServer) error {
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
+
+	if err != nil {
+		return errorToStatus(err)
+	}
+
+	endpoint := NewContextEndpoint(m.Impl)
+	messages := make(chan *TaggedMessage)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- receiveStream(srv.Context(), m.Impl, stub, messages)
+	}()
+
+	// the messages that are received once the host has stopped reading are Nacked, and the stub is only closed once
+	// receiving has returned
+	drain := func(reason error) {
+		go func() {
+			drainMessages(endpoint, stub, messages, done, reason)
+			closer()
+		}()
+	}
+
+	for {
+		select {
+		case r := <-messages:
+			err := srv.Send(&proto.ReceiveResponse{
+				Message: m.bodies.taggedToProto(r),
+			})
+
+			if err != nil {
+				endpoint.NackContext(context.Background(), stub, r.Tag, err)
+				drain(err)
+
+				return errorToStatus(err)
+			}
+		case err := <-done:
+			closer()
+
+			return errorToStatus(err)
+		case <-srv.Context().Done():
+			drain(srv.Context().Err())
+
+			return srv.Context().Err()
+		}
+	}
+}
+
 func (m *GRPCEndpointServer) Ack(ctx context.Context, req *proto.AckRequest) (*proto.AckResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	defer closer()
 
-	return &proto.AckResponse{}, m.Impl.Ack(stub, req.Tag, &Message{
-		Body:       req.Response.Body,
-		Attributes: req.Response.Attributes,
-	})
+	response, err := m.bodies.fromProto(req.Response)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &proto.AckResponse{}, errorToStatus(NewContextEndpoint(m.Impl).AckContext(ctx, stub, req.Tag, response))
 }
 
 func (m *GRPCEndpointServer) Nack(ctx context.Context, req *proto.NackRequest) (*proto.NackResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	defer closer()
 
-	return &proto.NackResponse{}, m.Impl.Nack(stub, req.Tag, errors.New(req.Error))
+	return &proto.NackResponse{}, errorToStatus(NewContextEndpoint(m.Impl).NackContext(ctx, stub, req.Tag, nackError(req)))
+}
+
+// nackError returns the error that the host passed to Nack, as an *Error if it was one.
+func nackError(req *proto.NackRequest) error {
+	return errorFromParts(req.Error, req.ErrorDetail)
 }
 
 func (m *GRPCEndpointServer) Close(ctx context.Context, req *proto.CloseRequest) (*proto.CloseResponse, error) {
-	stub, closer, err := SetupStubClient(m.broker, req.StubServer)
+	stub, closer, err := m.stubs.get(m.broker, req.StubServer)
 
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	defer closer()
+	defer m.stubs.disconnect()
+
+	return &proto.CloseResponse{}, errorToStatus(NewContextEndpoint(m.Impl).CloseContext(ctx, stub))
+}
+
+func (m *GRPCEndpointServer) UploadBody(srv proto.Endpoint_UploadBodyServer) error {
+	return m.bodies.uploadBody(srv)
+}
 
-	return &proto.CloseResponse{}, m.Impl.Close(stub)
+func (m *GRPCEndpointServer) DownloadBody(req *proto.DownloadBodyRequest, srv proto.Endpoint_DownloadBodyServer) error {
+	return m.bodies.downloadBody(req, srv)
 }
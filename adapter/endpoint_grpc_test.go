@@ -0,0 +1,223 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+type testStub struct {
+	KV
+	Secrets
+	Metrics
+}
+
+func (testStub) Printf(format string, v ...interface{}) {}
+func (testStub) Fatalf(format string, v ...interface{}) {}
+func (testStub) Panicf(format string, v ...interface{}) {}
+func (testStub) Debugf(format string, v ...interface{}) {}
+func (testStub) Warnf(format string, v ...interface{})  {}
+func (testStub) Errorf(format string, v ...interface{}) {}
+
+// logStub sends every Printf message on its channel.
+type logStub struct {
+	testStub
+	logs chan string
+}
+
+func (s *logStub) Printf(format string, v ...interface{}) {
+	s.logs <- fmt.Sprintf(format, v...)
+}
+
+var errDrained = errors.New("no more messages")
+
+// queueEndpoint receives the messages in its queue, in order, and fails once the queue has been drained.
+type queueEndpoint struct {
+	queue chan *TaggedMessage
+}
+
+func newQueueEndpoint(bodies ...string) *queueEndpoint {
+	e := &queueEndpoint{queue: make(chan *TaggedMessage, len(bodies))}
+
+	for _, body := range bodies {
+		e.queue <- NewTaggedMessage([]byte(body))
+	}
+
+	close(e.queue)
+
+	return e
+}
+
+func (e *queueEndpoint) Init(stub Stub, config []byte) error { return nil }
+
+func (e *queueEndpoint) Send(stub Stub, message *Message) (*Message, error) { return message, nil }
+
+func (e *queueEndpoint) Receive(stub Stub) (*TaggedMessage, error) {
+	message, ok := <-e.queue
+
+	if !ok {
+		return nil, errDrained
+	}
+
+	return message, nil
+}
+
+func (e *queueEndpoint) Ack(stub Stub, tag uint64, response *Message) error { return nil }
+
+func (e *queueEndpoint) Nack(stub Stub, tag uint64, err error) error { return nil }
+
+func (e *queueEndpoint) Close(stub Stub) error { return nil }
+
+func dispenseEndpoint(t testing.TB, impl Endpoint) *GRPCEndpointClient {
+	client, _ := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"endpoint": &EndpointPlugin{Impl: impl},
+	})
+
+	raw, err := client.Dispense("endpoint")
+
+	if err != nil {
+		t.Fatalf("failed to dispense endpoint: %v", err)
+	}
+
+	return raw.(*GRPCEndpointClient)
+}
+
+func TestGRPCEndpointReceiveStream(t *testing.T) {
+	endpoint := dispenseEndpoint(t, newQueueEndpoint("a", "b", "c"))
+
+	messages := make(chan *TaggedMessage, 3)
+	err := endpoint.ReceiveStream(testStub{}, messages)
+
+	if err == nil || err.Error() != "rpc error: code = Unknown desc = "+errDrained.Error() {
+		t.Fatalf("expected the stream to end with %q, got %v", errDrained, err)
+	}
+
+	close(messages)
+
+	var bodies string
+	for message := range messages {
+		bodies += string(message.Body)
+	}
+
+	if bodies != "abc" {
+		t.Fatalf("expected bodies %q, got %q", "abc", bodies)
+	}
+}
+
+// nackEndpoint is a queueEndpoint that sends the tags it is Nacked with on its channel.
+type nackEndpoint struct {
+	*queueEndpoint
+	nacks chan uint64
+}
+
+func (e *nackEndpoint) Nack(stub Stub, tag uint64, err error) error {
+	e.nacks <- tag
+
+	return nil
+}
+
+func TestGRPCEndpointReceiveStreamNacksUnread(t *testing.T) {
+	impl := &nackEndpoint{queueEndpoint: &queueEndpoint{queue: make(chan *TaggedMessage, 2)}, nacks: make(chan uint64, 2)}
+	first, second := NewTaggedMessage([]byte("a")), NewTaggedMessage([]byte("b"))
+	impl.queue <- first
+	impl.queue <- second
+
+	endpoint := dispenseEndpoint(t, impl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan *TaggedMessage)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- endpoint.ReceiveStreamContext(ctx, testStub{}, messages)
+	}()
+
+	if message := <-messages; message.Tag != first.Tag {
+		t.Fatalf("expected the first message, got %+v", message)
+	}
+
+	cancel()
+	<-done
+
+	select {
+	case tag := <-impl.nacks:
+		if tag != second.Tag {
+			t.Fatalf("expected the unread message to be Nacked, got tag %d", tag)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the unread message to be Nacked")
+	}
+}
+
+// backgroundLogEndpoint logs from a goroutine it starts in Init, after Init has returned.
+type backgroundLogEndpoint struct {
+	queueEndpoint
+	initialized chan struct{}
+}
+
+func (e *backgroundLogEndpoint) Init(stub Stub, config []byte) error {
+	go func() {
+		<-e.initialized
+		stub.Printf("still here")
+	}()
+
+	return nil
+}
+
+func TestGRPCEndpointStubOutlivesInit(t *testing.T) {
+	impl := &backgroundLogEndpoint{initialized: make(chan struct{})}
+	endpoint := dispenseEndpoint(t, impl)
+	stub := &logStub{logs: make(chan string, 1)}
+
+	if err := endpoint.Init(stub, nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	close(impl.initialized)
+
+	select {
+	case log := <-stub.logs:
+		if log != "still here" {
+			t.Fatalf("expected log %q, got %q", "still here", log)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the stub to be usable after Init")
+	}
+
+	if err := endpoint.Close(stub); err != nil {
+		t.Fatalf("failed to close endpoint: %v", err)
+	}
+}
+
+func TestGRPCEndpointMessageID(t *testing.T) {
+	impl := &queueEndpoint{queue: make(chan *TaggedMessage, 1)}
+	impl.queue <- NewTaggedMessage([]byte("a"), WithID("upstream-1"))
+	close(impl.queue)
+
+	endpoint := dispenseEndpoint(t, impl)
+
+	received, err := endpoint.Receive(testStub{})
+
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+
+	if received.ID != "upstream-1" {
+		t.Fatalf("expected received message ID %q, got %q", "upstream-1", received.ID)
+	}
+
+	message := NewMessage(nil)
+	response, err := endpoint.Send(testStub{}, message)
+
+	if err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	if response.ID != message.ID {
+		t.Fatalf("expected the echoed message ID %q, got %q", message.ID, response.ID)
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 // EndpointHandshake is a common handshake that is shared by plugin and host.
 var EndpointHandshake = plugin.HandshakeConfig{
-	ProtocolVersion:  2,
+	ProtocolVersion:  LegacyProtocolVersion,
 	MagicCookieKey:   "ADAPTER_PLUGIN",
 	MagicCookieValue: "endpoint",
 }
@@ -29,21 +29,52 @@ type EndpointPlugin struct {
 	// Concrete implementation, written in Go. This is  only used for plugins
 	// that are written in Go.
 	Impl Endpoint
+
+	// Limits bound the message bodies that are streamed in chunks; the zero value uses the defaults.
+	Limits Limits
+
+	// Manifest is what the plugin declares about itself; only used by the plugin.
+	Manifest Manifest
 }
 
 func (p *EndpointPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
-	proto.RegisterEndpointServer(s, &GRPCEndpointServer{
-		Impl:   p.Impl,
-		broker: broker,
-	})
+	registerEndpointServer(s, broker, p)
 	return nil
 }
 
 func (p *EndpointPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
-	return &GRPCEndpointClient{
+	return newEndpointClient(c, broker, p.Limits), nil
+}
+
+// registerEndpointServer serves the implementation of `p` on `s`. The server reaches the stub of the host through
+// `broker`.
+func registerEndpointServer(s *grpc.Server, broker Broker, p *EndpointPlugin) {
+	proto.RegisterEndpointServer(s, &GRPCEndpointServer{
+		Impl:     p.Impl,
+		broker:   broker,
+		bodies:   chunkServer{limits: p.Limits},
+		manifest: p.Manifest,
+	})
+}
+
+func newEndpointClient(c *grpc.ClientConn, broker Broker, limits Limits) *GRPCEndpointClient {
+	m := &GRPCEndpointClient{
 		client: proto.NewEndpointClient(c),
 		broker: broker,
-	}, nil
+	}
+
+	m.bodies = chunkClient{
+		limits: limits,
+		upload: func(ctx context.Context) (bodyUploadClient, error) {
+			return m.client.UploadBody(ctx)
+		},
+		download: func(ctx context.Context, req *proto.DownloadBodyRequest) (bodyDownloadClient, error) {
+			return m.client.DownloadBody(ctx, req)
+		},
+		describe: m.Describe,
+	}
+
+	return m
 }
 
 var _ plugin.GRPCPlugin = &EndpointPlugin{}
@@ -0,0 +1,75 @@
+package adapter
+
+import (
+	"errors"
+	"time"
+)
+
+// Error is an error that keeps its classification across the plugin boundary, so that the host can decide whether to
+// retry a message, dead-letter it or drop it. Endpoints and actions can return it from any call, and the host gets it
+// back as an *Error; Nack passes it on to the input endpoint the same way.
+type Error struct {
+	// Code identifies the kind of error, e.g. "rate_limited" or "invalid_payload"
+	Code    string
+	Message string
+
+	// Retryable errors are expected to go away when the message is retried; all other errors are permanent
+	Retryable bool
+
+	// RetryAfter is how long to wait before retrying, or 0 if the error doesn't say
+	RetryAfter time.Duration
+
+	Details map[string]string
+}
+
+// NewError returns a permanent error.
+func NewError(code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// NewRetryableError returns a retryable error, which should be retried after `retryAfter` if that is not 0.
+func NewRetryableError(code, message string, retryAfter time.Duration) *Error {
+	return &Error{
+		Code:       code,
+		Message:    message,
+		Retryable:  true,
+		RetryAfter: retryAfter,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+
+	return e.Code + ": " + e.Message
+}
+
+// AsError returns the first *Error in the chain of `err`.
+func AsError(err error) (*Error, bool) {
+	var e *Error
+
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+// IsRetryable reports whether `err` is a retryable *Error.
+func IsRetryable(err error) bool {
+	e, ok := AsError(err)
+
+	return ok && e.Retryable
+}
+
+// IsPermanent reports whether `err` is an *Error that is not retryable. Errors that are not an *Error are neither
+// retryable nor permanent; it is up to the host to decide what to do with them.
+func IsPermanent(err error) bool {
+	e, ok := AsError(err)
+
+	return ok && !e.Retryable
+}
@@ -0,0 +1,134 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func errorToProto(err error) *proto.ErrorDetail {
+	e, ok := AsError(err)
+
+	if !ok {
+		return nil
+	}
+
+	detail := &proto.ErrorDetail{
+		Code:      e.Code,
+		Message:   e.Message,
+		Retryable: e.Retryable,
+		Details:   e.Details,
+		Error:     err.Error(),
+	}
+
+	if e.RetryAfter != 0 {
+		detail.RetryAfter = ptypes.DurationProto(e.RetryAfter)
+	}
+
+	return detail
+}
+
+// errorFromProto rebuilds the error that `detail` was taken from: its *Error, wrapped in an error with the message of
+// the original error if that wrapped it. Peers that predate ErrorDetail.error send that message as `message`.
+func errorFromProto(detail *proto.ErrorDetail, message string) error {
+	e := &Error{
+		Code:      detail.Code,
+		Message:   detail.Message,
+		Retryable: detail.Retryable,
+		Details:   detail.Details,
+	}
+
+	if detail.RetryAfter != nil {
+		e.RetryAfter, _ = ptypes.Duration(detail.RetryAfter)
+	}
+
+	if detail.Error != "" {
+		message = detail.Error
+	}
+
+	if message == "" || message == e.Error() {
+		return e
+	}
+
+	return &wrappedError{message: message, err: e}
+}
+
+// wrappedError is an *Error that was wrapped by another error on the other side of the plugin boundary.
+type wrappedError struct {
+	message string
+	err     *Error
+}
+
+func (w *wrappedError) Error() string {
+	return w.message
+}
+
+func (w *wrappedError) Unwrap() error {
+	return w.err
+}
+
+// errorToStatus converts an error returned by an implementation into the error returned by its gRPC server, which
+// carries an ErrorDetail in its status if it is an *Error.
+func errorToStatus(err error) error {
+	detail := errorToProto(err)
+
+	if detail == nil {
+		return err
+	}
+
+	s, serr := status.New(codes.Unknown, err.Error()).WithDetails(detail)
+
+	if serr != nil {
+		return err
+	}
+
+	return s.Err()
+}
+
+// errorFromStatus converts an error returned by a gRPC client back into the *Error returned by the implementation on
+// the other side, if it was one.
+func errorFromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	s, ok := status.FromError(err)
+
+	if !ok {
+		return err
+	}
+
+	for _, detail := range s.Details() {
+		if detail, ok := detail.(*proto.ErrorDetail); ok {
+			return errorFromProto(detail, s.Message())
+		}
+	}
+
+	return err
+}
+
+// errorFromCall converts the error of a gRPC call made with `ctx` like errorFromStatus does. A call that failed
+// because `ctx` is done returns ctx.Err(), like the implementation would if it were called in process.
+func errorFromCall(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		switch status.Code(err) {
+		case codes.Canceled, codes.DeadlineExceeded:
+			return ctx.Err()
+		}
+	}
+
+	return errorFromStatus(err)
+}
+
+// errorFromParts rebuilds an error that crossed the plugin boundary as its message and, for an *Error, its detail.
+func errorFromParts(message string, detail *proto.ErrorDetail) error {
+	if detail != nil {
+		return errorFromProto(detail, message)
+	}
+
+	return errors.New(message)
+}
@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// failingEndpoint fails every Send with its error and hands every error it is Nacked with to its channel.
+type failingEndpoint struct {
+	queueEndpoint
+	err    error
+	nacked chan error
+}
+
+func (e *failingEndpoint) Send(stub Stub, message *Message) (*Message, error) {
+	return nil, e.err
+}
+
+func (e *failingEndpoint) Nack(stub Stub, tag uint64, err error) error {
+	e.nacked <- err
+
+	return nil
+}
+
+func TestGRPCEndpointError(t *testing.T) {
+	expected := NewRetryableError("rate_limited", "too many requests", 3*time.Second)
+	expected.Details = map[string]string{"limit": "100"}
+
+	impl := &failingEndpoint{err: expected, nacked: make(chan error, 1)}
+	endpoint := dispenseEndpoint(t, impl)
+
+	_, err := endpoint.Send(testStub{}, NewMessage(nil))
+
+	if e, ok := AsError(err); !ok || !reflect.DeepEqual(e, expected) {
+		t.Fatalf("expected error %#v, got %#v", expected, err)
+	}
+
+	if !IsRetryable(err) || IsPermanent(err) {
+		t.Fatalf("expected error %v to be retryable", err)
+	}
+
+	if err := endpoint.Nack(testStub{}, 1, NewError("invalid_payload", "not json")); err != nil {
+		t.Fatalf("failed to nack: %v", err)
+	}
+
+	if err := <-impl.nacked; !IsPermanent(err) || err.Error() != "invalid_payload: not json" {
+		t.Fatalf("expected a permanent error to be nacked, got %#v", err)
+	}
+
+	impl.err = errors.New("plain")
+
+	if _, err := endpoint.Send(testStub{}, NewMessage(nil)); IsRetryable(err) || IsPermanent(err) {
+		t.Fatalf("expected a plain error to be unclassified, got %#v", err)
+	}
+}
+
+func TestGRPCEndpointWrappedError(t *testing.T) {
+	wrapped := fmt.Errorf("sending order 42: %w", NewRetryableError("rate_limited", "too many requests", 0))
+
+	impl := &failingEndpoint{err: wrapped, nacked: make(chan error, 1)}
+	endpoint := dispenseEndpoint(t, impl)
+
+	_, err := endpoint.Send(testStub{}, NewMessage(nil))
+
+	if !IsRetryable(err) || err.Error() != wrapped.Error() {
+		t.Fatalf("expected error %q to stay retryable, got %#v", wrapped, err)
+	}
+
+	if err := endpoint.Nack(testStub{}, 1, wrapped); err != nil {
+		t.Fatalf("failed to nack: %v", err)
+	}
+
+	if err := <-impl.nacked; !IsRetryable(err) || err.Error() != wrapped.Error() {
+		t.Fatalf("expected error %q to be nacked, got %#v", wrapped, err)
+	}
+}
@@ -0,0 +1,84 @@
+package adapter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/unchainio/interfaces/logger"
+)
+
+// FatalError is the Fatalf or Panicf call with which a plugin made itself unhealthy.
+//
+// Fatalf and Panicf never end the host: it logs them at the error level, with the original level in the plugin_level
+// field, and its client of the plugin reports the first one through Unhealthy. A plugin in a process of its own is
+// terminated once the host has received the message: Fatalf exits the process and Panicf panics. Plugins that run
+// within the host process only log.
+type FatalError struct {
+	Level   logger.Level
+	Message string
+}
+
+func (e *FatalError) Error() string {
+	return fmt.Sprintf("adapter: the plugin logged a %s error: %s", strings.ToLower(e.Level.String()), e.Message)
+}
+
+// isFatal reports whether `level` is that of Fatalf or Panicf.
+func isFatal(level logger.Level) bool {
+	return level == logger.FatalLevel || level == logger.PanicLevel
+}
+
+// downgradeFatal returns `record` at the error level if it has been logged with Fatalf or Panicf, so that logging it
+// never exits or panics.
+func downgradeFatal(record logger.Record) logger.Record {
+	if !isFatal(record.Level) {
+		return record
+	}
+
+	record.Fields = append(append([]logger.Field(nil), record.Fields...), logger.Field{
+		Key:   "plugin_level",
+		Value: record.Level.String(),
+	})
+	record.Level = logger.ErrorLevel
+
+	return record
+}
+
+// health keeps the first Fatalf or Panicf call of a plugin.
+type health struct {
+	mu    sync.Mutex
+	fatal *FatalError
+}
+
+func (h *health) record(record logger.Record) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.fatal == nil {
+		h.fatal = &FatalError{Level: record.Level, Message: record.Message}
+	}
+}
+
+func (h *health) unhealthy() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.fatal == nil {
+		return nil
+	}
+
+	return h.fatal
+}
+
+// osExit is replaced by tests that cover the termination of plugins.
+var osExit = os.Exit
+
+// terminate ends the plugin process after a Fatalf or Panicf call has been delivered to the host.
+func terminate(record logger.Record) {
+	if record.Level == logger.PanicLevel {
+		panic(record.Message)
+	}
+
+	osExit(1)
+}
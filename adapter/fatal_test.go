@@ -0,0 +1,115 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc"
+)
+
+// fatalEndpoint calls Fatalf at Init.
+type fatalEndpoint struct {
+	queueEndpoint
+}
+
+func (e *fatalEndpoint) Init(stub Stub, config []byte) error {
+	stub.Fatalf("invalid config %s", config)
+
+	return nil
+}
+
+// replaceExit makes osExit send the exit code on the returned channel, until restore is called.
+func replaceExit() (codes <-chan int, restore func()) {
+	c := make(chan int, 1)
+	exit := osExit
+
+	osExit = func(code int) { c <- code }
+
+	return c, func() { osExit = exit }
+}
+
+func TestGRPCStubFatalf(t *testing.T) {
+	exits, restore := replaceExit()
+	defer restore()
+	log := &recordLogger{records: make(chan logger.Record, 1)}
+
+	endpoint := dispenseEndpoint(t, &fatalEndpoint{})
+
+	if err := endpoint.Init(NewStub(log), []byte("x")); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	if code := <-exits; code != 1 {
+		t.Fatalf("expected the plugin to exit with code 1, got %d", code)
+	}
+
+	record := <-log.records
+
+	if record.Level != logger.ErrorLevel || record.Message != "invalid config x" {
+		t.Fatalf("expected the host to log the message as an error, got %+v", record)
+	}
+
+	if len(record.Fields) != 1 || record.Fields[0].Value != "FATAL" {
+		t.Fatalf("expected the record to keep the level of the plugin as a field, got %v", record.Fields)
+	}
+
+	fatal, ok := endpoint.Unhealthy().(*FatalError)
+
+	if !ok || fatal.Level != logger.FatalLevel || fatal.Message != "invalid config x" {
+		t.Fatalf("expected the endpoint to be unhealthy, got %v", endpoint.Unhealthy())
+	}
+}
+
+func TestGRPCStubPanicf(t *testing.T) {
+	log := &recordLogger{records: make(chan logger.Record, 1)}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		proto.RegisterStubHelperServer(s, &GRPCStubServer{Impl: NewStub(log)})
+	})
+
+	defer server.Stop()
+	defer conn.Close()
+
+	stub := &GRPCStubHelperClient{client: proto.NewStubHelperClient(conn), exits: true}
+
+	defer func() {
+		if r := recover(); r != "lost 3 records" {
+			t.Fatalf("expected Panicf to panic with its message, got %v", r)
+		}
+
+		if record := <-log.records; record.Level != logger.ErrorLevel || record.Message != "lost 3 records" {
+			t.Fatalf("expected the host to log the message as an error before the panic, got %+v", record)
+		}
+	}()
+
+	stub.Panicf("lost %d records", 3)
+}
+
+func TestInProcessFatalf(t *testing.T) {
+	exits, restore := replaceExit()
+	defer restore()
+
+	endpoint, err := NewInProcessEndpoint(&fatalEndpoint{})
+
+	if err != nil {
+		t.Fatalf("failed to serve endpoint in-process: %v", err)
+	}
+
+	defer endpoint.Close(testStub{})
+
+	if err := endpoint.Init(testStub{}, []byte("x")); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	select {
+	case <-exits:
+		t.Fatalf("expected an in-process plugin not to exit the host")
+	default:
+	}
+
+	if endpoint.Unhealthy() == nil {
+		t.Fatalf("expected the endpoint to be unhealthy")
+	}
+}
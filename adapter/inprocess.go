@@ -0,0 +1,409 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// InProcessEndpoint is an endpoint served over gRPC within this process, through the same client and server as an
+// endpoint plugin, but over an in-memory connection instead of a subprocess. Closing it closes the endpoint and then
+// stops the server.
+type InProcessEndpoint struct {
+	*GRPCEndpointClient
+	stop func()
+}
+
+// NewInProcessEndpoint serves `endpoint` in-process and returns its client.
+func NewInProcessEndpoint(endpoint Endpoint) (*InProcessEndpoint, error) {
+	broker := newMemoryBroker()
+
+	conn, stop, err := serveInProcess(func(s *grpc.Server) {
+		registerEndpointServer(s, broker, &EndpointPlugin{Impl: endpoint})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &InProcessEndpoint{
+		GRPCEndpointClient: newEndpointClient(conn, broker, Limits{}),
+		stop:               stop,
+	}, nil
+}
+
+func (e *InProcessEndpoint) Close(stub Stub) error {
+	return e.CloseContext(context.Background(), stub)
+}
+
+func (e *InProcessEndpoint) CloseContext(ctx context.Context, stub Stub) error {
+	defer e.stop()
+
+	return e.GRPCEndpointClient.CloseContext(ctx, stub)
+}
+
+// InProcessAction is an action served over gRPC within this process, through the same client and server as an action
+// plugin, but over an in-memory connection instead of a subprocess.
+type InProcessAction struct {
+	*GRPCActionClient
+	stop func()
+}
+
+// NewInProcessAction serves `action` in-process and returns its client.
+func NewInProcessAction(action Action) (*InProcessAction, error) {
+	broker := newMemoryBroker()
+
+	conn, stop, err := serveInProcess(func(s *grpc.Server) {
+		registerActionServer(s, broker, &ActionPlugin{Impl: action})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &InProcessAction{
+		GRPCActionClient: newActionClient(conn, broker, Limits{}),
+		stop:             stop,
+	}, nil
+}
+
+// Close disconnects the action from its stub, which delivers the logs and metrics that it buffered, stops serving the
+// stub and stops the server.
+func (a *InProcessAction) Close() error {
+	defer a.stop()
+
+	return a.GRPCActionClient.Close()
+}
+
+// serveInProcess starts a gRPC server with the services registered by `register` on an in-memory listener, and
+// returns a connection to it.
+func serveInProcess(register func(s *grpc.Server)) (conn *grpc.ClientConn, stop func(), err error) {
+	listener := newMemoryListener()
+	server := grpc.NewServer()
+
+	register(server)
+
+	go server.Serve(listener)
+
+	conn, err = grpc.Dial("inprocess", grpc.WithInsecure(), grpc.WithDialer(listener.dial))
+
+	if err != nil {
+		server.Stop()
+
+		return nil, nil, err
+	}
+
+	return conn, func() {
+		conn.Close()
+		server.Stop()
+	}, nil
+}
+
+var errListenerClosed = errors.New("adapter: in-memory listener is closed")
+
+// memoryListener is a net.Listener whose connections are in-memory pipes.
+type memoryListener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newMemoryListener() *memoryListener {
+	return &memoryListener{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+func (l *memoryListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, errListenerClosed
+	}
+}
+
+func (l *memoryListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+
+	return nil
+}
+
+func (l *memoryListener) Addr() net.Addr {
+	return memoryAddr{}
+}
+
+// dial connects to the listener, waiting at most `timeout` for it to accept the connection.
+func (l *memoryListener) dial(addr string, timeout time.Duration) (net.Conn, error) {
+	client, server := net.Pipe()
+
+	var expired <-chan time.Time
+
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		return nil, errListenerClosed
+	case <-expired:
+		return nil, errors.New("adapter: timed out dialing in-memory listener")
+	}
+}
+
+type memoryAddr struct{}
+
+func (memoryAddr) Network() string { return "memory" }
+func (memoryAddr) String() string  { return "inprocess" }
+
+// memoryBroker is a Broker whose stub servers listen on in-memory listeners. The listener of an ID exists from NextId
+// until its server stops.
+type memoryBroker struct {
+	mu        sync.Mutex
+	nextID    uint32
+	listeners map[uint32]*memoryListener
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{listeners: make(map[uint32]*memoryListener)}
+}
+
+func (b *memoryBroker) NextId() uint32 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	b.listeners[b.nextID] = newMemoryListener()
+
+	return b.nextID
+}
+
+// listener returns the listener of `id`, or nil if `id` was not handed out by NextId or its server has stopped.
+func (b *memoryBroker) listener(id uint32) *memoryListener {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.listeners[id]
+}
+
+func (b *memoryBroker) AcceptAndServe(id uint32, s func([]grpc.ServerOption) *grpc.Server) {
+	l := b.listener(id)
+
+	if l == nil {
+		return
+	}
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.listeners, id)
+		b.mu.Unlock()
+
+		l.Close()
+	}()
+
+	s(nil).Serve(l)
+}
+
+// Dial connects to the server of `id`, which may start serving later. It fails once that server has stopped, rather
+// than waiting for a server that never comes.
+func (b *memoryBroker) Dial(id uint32) (*grpc.ClientConn, error) {
+	l := b.listener(id)
+
+	if l == nil {
+		return nil, fmt.Errorf("adapter: there is no server with broker ID %d; it may have stopped", id)
+	}
+
+	return grpc.Dial("inprocess", grpc.WithInsecure(), grpc.WithDialer(l.dial))
+}
+
+// NewDirectEndpoint returns an endpoint that calls `endpoint` directly, without gRPC. Like a plugin, the endpoint gets
+// its own copy of every message passed to it, and the caller gets its own copy of every message returned. The result
+// also implements ContextEndpoint.
+func NewDirectEndpoint(endpoint Endpoint) Endpoint {
+	return &directEndpoint{endpoint: endpoint, impl: NewContextEndpoint(endpoint)}
+}
+
+type directEndpoint struct {
+	endpoint Endpoint
+	impl     ContextEndpoint
+}
+
+func (e *directEndpoint) Init(stub Stub, config []byte) error {
+	return e.InitContext(context.Background(), stub, config)
+}
+
+func (e *directEndpoint) InitContext(ctx context.Context, stub Stub, config []byte) error {
+	return e.impl.InitContext(ctx, stub, append([]byte(nil), config...))
+}
+
+func (e *directEndpoint) Send(stub Stub, message *Message) (*Message, error) {
+	return e.SendContext(context.Background(), stub, message)
+}
+
+func (e *directEndpoint) SendContext(ctx context.Context, stub Stub, message *Message) (*Message, error) {
+	response, err := e.impl.SendContext(ctx, stub, cloneMessage(message))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneMessage(response), nil
+}
+
+func (e *directEndpoint) SendBatch(stub Stub, messages []*Message) ([]BatchResult, error) {
+	return e.SendBatchContext(context.Background(), stub, messages)
+}
+
+func (e *directEndpoint) SendBatchContext(ctx context.Context, stub Stub, messages []*Message) ([]BatchResult, error) {
+	sent := make([]*Message, len(messages))
+
+	for i, message := range messages {
+		sent[i] = cloneMessage(message)
+	}
+
+	results, err := SendBatch(ctx, e.endpoint, stub, sent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Response = cloneMessage(results[i].Response)
+	}
+
+	return results, nil
+}
+
+func (e *directEndpoint) Receive(stub Stub) (*TaggedMessage, error) {
+	return e.ReceiveContext(context.Background(), stub)
+}
+
+func (e *directEndpoint) ReceiveContext(ctx context.Context, stub Stub) (*TaggedMessage, error) {
+	message, err := e.impl.ReceiveContext(ctx, stub)
+
+	if err != nil || message == nil {
+		return nil, err
+	}
+
+	return &TaggedMessage{
+		Tag:     message.Tag,
+		Message: cloneMessage(message.Message),
+	}, nil
+}
+
+func (e *directEndpoint) Ack(stub Stub, tag uint64, response *Message) error {
+	return e.AckContext(context.Background(), stub, tag, response)
+}
+
+func (e *directEndpoint) AckContext(ctx context.Context, stub Stub, tag uint64, response *Message) error {
+	return e.impl.AckContext(ctx, stub, tag, cloneMessage(response))
+}
+
+func (e *directEndpoint) Nack(stub Stub, tag uint64, err error) error {
+	return e.NackContext(context.Background(), stub, tag, err)
+}
+
+func (e *directEndpoint) NackContext(ctx context.Context, stub Stub, tag uint64, err error) error {
+	return e.impl.NackContext(ctx, stub, tag, err)
+}
+
+func (e *directEndpoint) Close(stub Stub) error {
+	return e.CloseContext(context.Background(), stub)
+}
+
+func (e *directEndpoint) CloseContext(ctx context.Context, stub Stub) error {
+	return e.impl.CloseContext(ctx, stub)
+}
+
+// NewDirectAction returns an action that calls `action` directly, without gRPC. Like a plugin, the action works on its
+// own copy of the message, which is copied back into the message of the caller when Invoke succeeds. The result also
+// implements ContextAction.
+func NewDirectAction(action Action) Action {
+	return &directAction{action: action, impl: NewContextAction(action)}
+}
+
+type directAction struct {
+	action Action
+	impl   ContextAction
+}
+
+func (a *directAction) Init(stub Stub, config []byte) error {
+	return a.InitContext(context.Background(), stub, config)
+}
+
+func (a *directAction) InitContext(ctx context.Context, stub Stub, config []byte) error {
+	return a.impl.InitContext(ctx, stub, append([]byte(nil), config...))
+}
+
+func (a *directAction) Invoke(stub Stub, message *Message) error {
+	return a.InvokeContext(context.Background(), stub, message)
+}
+
+func (a *directAction) InvokeContext(ctx context.Context, stub Stub, message *Message) error {
+	invoked := cloneMessage(message)
+
+	if err := a.impl.InvokeContext(ctx, stub, invoked); err != nil {
+		return err
+	}
+
+	*message = *cloneMessage(invoked)
+
+	return nil
+}
+
+func (a *directAction) InvokeMulti(stub Stub, message *Message) ([]*Message, error) {
+	return a.InvokeMultiContext(context.Background(), stub, message)
+}
+
+func (a *directAction) InvokeMultiContext(ctx context.Context, stub Stub, message *Message) ([]*Message, error) {
+	messages, err := InvokeMulti(ctx, a.action, stub, cloneMessage(message))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range messages {
+		messages[i] = cloneMessage(messages[i])
+	}
+
+	return messages, nil
+}
+
+func (a *directAction) InvokeBatch(stub Stub, messages []*Message) ([]error, error) {
+	return a.InvokeBatchContext(context.Background(), stub, messages)
+}
+
+func (a *directAction) InvokeBatchContext(ctx context.Context, stub Stub, messages []*Message) ([]error, error) {
+	invoked := make([]*Message, len(messages))
+
+	for i, message := range messages {
+		invoked[i] = cloneMessage(message)
+	}
+
+	errs, err := InvokeBatch(ctx, a.action, stub, invoked)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, message := range messages {
+		if errs[i] == nil {
+			*message = *cloneMessage(invoked[i])
+		}
+	}
+
+	return errs, nil
+}
@@ -0,0 +1,188 @@
+package adapter
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// suffixAction appends its config to the body of every message.
+type suffixAction struct {
+	suffix []byte
+}
+
+func (a *suffixAction) Init(stub Stub, config []byte) error {
+	a.suffix = config
+
+	return nil
+}
+
+func (a *suffixAction) Invoke(stub Stub, message *Message) error {
+	message.Body = append(message.Body, a.suffix...)
+
+	return nil
+}
+
+func TestInProcessEndpoint(t *testing.T) {
+	impl := &backgroundLogEndpoint{
+		queueEndpoint: *newQueueEndpoint("hello"),
+		initialized:   make(chan struct{}),
+	}
+
+	endpoint, err := NewInProcessEndpoint(impl)
+
+	if err != nil {
+		t.Fatalf("failed to serve endpoint in-process: %v", err)
+	}
+
+	stub := &logStub{logs: make(chan string, 1)}
+
+	if err := endpoint.Init(stub, nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	close(impl.initialized)
+
+	select {
+	case log := <-stub.logs:
+		if log != "still here" {
+			t.Fatalf("expected log %q, got %q", "still here", log)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the endpoint to log through the stub")
+	}
+
+	message, err := endpoint.Receive(stub)
+
+	if err != nil || string(message.Body) != "hello" {
+		t.Fatalf("expected to receive %q, got %v, %v", "hello", message, err)
+	}
+
+	if err := endpoint.Close(stub); err != nil {
+		t.Fatalf("failed to close endpoint: %v", err)
+	}
+
+	if _, err := endpoint.Receive(stub); err == nil {
+		t.Fatalf("expected Receive to fail after Close")
+	}
+}
+
+func TestInProcessAction(t *testing.T) {
+	action, err := NewInProcessAction(&suffixAction{})
+
+	if err != nil {
+		t.Fatalf("failed to serve action in-process: %v", err)
+	}
+
+	defer action.Close()
+
+	if err := action.Init(testStub{}, []byte("!")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	message := NewMessage([]byte("hello"))
+
+	if err := action.Invoke(testStub{}, message); err != nil || string(message.Body) != "hello!" {
+		t.Fatalf("expected body %q, got %q, %v", "hello!", message.Body, err)
+	}
+}
+
+func TestDirectEndpointCopiesMessages(t *testing.T) {
+	impl := newQueueEndpoint()
+	endpoint := NewDirectEndpoint(impl)
+
+	message := NewMessage([]byte("hello"))
+	response, err := endpoint.Send(testStub{}, message)
+
+	if err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	if response == message || string(response.Body) != "hello" {
+		t.Fatalf("expected a copy of the message as the response, got %v", response)
+	}
+
+	if _, ok := endpoint.(ContextEndpoint); !ok {
+		t.Fatalf("expected a direct endpoint to implement ContextEndpoint")
+	}
+}
+
+func TestDirectAction(t *testing.T) {
+	action := NewDirectAction(&suffixAction{})
+
+	if err := action.Init(testStub{}, []byte("!")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	message := NewMessage([]byte("hello"))
+
+	if err := action.Invoke(testStub{}, message); err != nil || string(message.Body) != "hello!" {
+		t.Fatalf("expected body %q, got %q, %v", "hello!", message.Body, err)
+	}
+}
+
+func TestMemoryBrokerDialAfterStop(t *testing.T) {
+	broker := newMemoryBroker()
+	id := broker.NextId()
+	server := grpc.NewServer()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		broker.AcceptAndServe(id, func([]grpc.ServerOption) *grpc.Server { return server })
+	}()
+
+	conn, err := broker.Dial(id)
+
+	if err != nil {
+		t.Fatalf("failed to dial a running server: %v", err)
+	}
+
+	conn.Close()
+	server.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected AcceptAndServe to return once the server has stopped")
+	}
+
+	if _, err := broker.Dial(id); err == nil {
+		t.Fatalf("expected dialing a stopped server to fail")
+	}
+}
+
+func TestInProcessActionCloseDisconnectsStub(t *testing.T) {
+	count := func() int {
+		pipelines.mu.Lock()
+		defer pipelines.mu.Unlock()
+
+		return len(pipelines.set)
+	}
+
+	before := count()
+
+	action, err := NewInProcessAction(&suffixAction{})
+
+	if err != nil {
+		t.Fatalf("failed to serve action in-process: %v", err)
+	}
+
+	if err := action.Init(testStub{}, nil); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	if n := count(); n != before+1 {
+		t.Fatalf("expected the action to be connected to its stub, got %d log pipelines instead of %d", n, before+1)
+	}
+
+	if err := action.Close(); err != nil {
+		t.Fatalf("failed to close action: %v", err)
+	}
+
+	if n := count(); n != before {
+		t.Fatalf("expected the action to be disconnected from its stub, got %d log pipelines instead of %d", n, before)
+	}
+}
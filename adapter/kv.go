@@ -0,0 +1,30 @@
+package adapter
+
+import "errors"
+
+// ErrKeyNotFound is returned by KV.Get if there is no value stored under the key.
+var ErrKeyNotFound = errors.New("key not found")
+
+// KV is a key-value store that the host provides to a plugin through its Stub.
+type KV interface {
+	// Get returns the value stored under `key`, or ErrKeyNotFound if there is none
+	Get(key string) (value []byte, err error)
+
+	// Put stores `value` under `key`, replacing any previous value
+	Put(key string, value []byte) error
+
+	// Delete removes the value stored under `key`. Deleting a key that does not exist is not an error.
+	Delete(key string) error
+
+	// List returns all pairs whose key starts with `prefix`, sorted by key
+	List(prefix string) (pairs []KVPair, err error)
+
+	// CompareAndSwap stores `value` under `key` only if the value currently stored equals `old`, where a nil `old`
+	// means that no value may be stored yet. It reports whether `value` has been stored.
+	CompareAndSwap(key string, old, value []byte) (swapped bool, err error)
+}
+
+type KVPair struct {
+	Key   string
+	Value []byte
+}
@@ -0,0 +1,137 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// FileKV is a KV that keeps its values in memory and writes all of them to a JSON file after every change, so that
+// they survive restarts of the host. It is safe for concurrent use, but the file must not be shared between FileKVs.
+type FileKV struct {
+	path   string
+	mu     sync.Mutex
+	memory *MemoryKV
+}
+
+// NewFileKV returns a FileKV that stores its values in the file at `path`, loading the values already stored there.
+func NewFileKV(path string) (*FileKV, error) {
+	kv := &FileKV{
+		path:   path,
+		memory: NewMemoryKV(),
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return kv, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]byte)
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	for key, value := range values {
+		kv.memory.values[key] = copyBytes(value)
+	}
+
+	return kv, nil
+}
+
+func (kv *FileKV) Get(key string) ([]byte, error) {
+	return kv.memory.Get(key)
+}
+
+func (kv *FileKV) Put(key string, value []byte) error {
+	_, err := kv.update(func(values map[string][]byte) bool {
+		values[key] = copyBytes(value)
+
+		return true
+	})
+
+	return err
+}
+
+func (kv *FileKV) Delete(key string) error {
+	_, err := kv.update(func(values map[string][]byte) bool {
+		_, ok := values[key]
+		delete(values, key)
+
+		return ok
+	})
+
+	return err
+}
+
+func (kv *FileKV) List(prefix string) ([]KVPair, error) {
+	return kv.memory.List(prefix)
+}
+
+func (kv *FileKV) CompareAndSwap(key string, old, value []byte) (bool, error) {
+	return kv.update(func(values map[string][]byte) bool {
+		current, ok := values[key]
+
+		if ok != (old != nil) || !bytes.Equal(current, old) {
+			return false
+		}
+
+		values[key] = copyBytes(value)
+
+		return true
+	})
+}
+
+// update applies `change` to a copy of the stored values and, if it reports a change, writes the copy to the file
+// before replacing the values in memory with it. It reports whether the values have been changed.
+func (kv *FileKV) update(change func(values map[string][]byte) bool) (bool, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	kv.memory.mu.RLock()
+	values := make(map[string][]byte, len(kv.memory.values))
+
+	for key, value := range kv.memory.values {
+		values[key] = value
+	}
+
+	kv.memory.mu.RUnlock()
+
+	if !change(values) {
+		return false, nil
+	}
+
+	if err := kv.save(values); err != nil {
+		return false, err
+	}
+
+	kv.memory.mu.Lock()
+	kv.memory.values = values
+	kv.memory.mu.Unlock()
+
+	return true, nil
+}
+
+// save replaces the file with `values` atomically, by writing a temporary file next to it and renaming that.
+func (kv *FileKV) save(values map[string][]byte) error {
+	data, err := json.Marshal(values)
+
+	if err != nil {
+		return err
+	}
+
+	tmp := kv.path + ".tmp"
+
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, kv.path)
+}
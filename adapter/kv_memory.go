@@ -0,0 +1,93 @@
+package adapter
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryKV is a KV that keeps its values in memory. It is safe for concurrent use.
+type MemoryKV struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{
+		values: make(map[string][]byte),
+	}
+}
+
+func (kv *MemoryKV) Get(key string) ([]byte, error) {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	value, ok := kv.values[key]
+
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return copyBytes(value), nil
+}
+
+func (kv *MemoryKV) Put(key string, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	kv.values[key] = copyBytes(value)
+
+	return nil
+}
+
+func (kv *MemoryKV) Delete(key string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	delete(kv.values, key)
+
+	return nil
+}
+
+func (kv *MemoryKV) List(prefix string) ([]KVPair, error) {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	var pairs []KVPair
+
+	for key, value := range kv.values {
+		if strings.HasPrefix(key, prefix) {
+			pairs = append(pairs, KVPair{Key: key, Value: copyBytes(value)})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key < pairs[j].Key
+	})
+
+	return pairs, nil
+}
+
+func (kv *MemoryKV) CompareAndSwap(key string, old, value []byte) (bool, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	current, ok := kv.values[key]
+
+	if ok != (old != nil) || !bytes.Equal(current, old) {
+		return false, nil
+	}
+
+	kv.values[key] = copyBytes(value)
+
+	return true, nil
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,113 @@
+package adapter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc"
+)
+
+func testKV(t *testing.T, kv KV) {
+	if _, err := kv.Get("cursor"); err != ErrKeyNotFound {
+		t.Fatalf("expected %v, got %v", ErrKeyNotFound, err)
+	}
+
+	if swapped, err := kv.CompareAndSwap("cursor", nil, []byte("1")); err != nil || !swapped {
+		t.Fatalf("expected the swap of a missing key to succeed, got %v, %v", swapped, err)
+	}
+
+	if swapped, err := kv.CompareAndSwap("cursor", nil, []byte("2")); err != nil || swapped {
+		t.Fatalf("expected the swap of an existing key with nil to fail, got %v, %v", swapped, err)
+	}
+
+	if swapped, err := kv.CompareAndSwap("cursor", []byte("1"), []byte("2")); err != nil || !swapped {
+		t.Fatalf("expected the swap of a matching value to succeed, got %v, %v", swapped, err)
+	}
+
+	if value, err := kv.Get("cursor"); err != nil || string(value) != "2" {
+		t.Fatalf("expected value %q, got %q, %v", "2", value, err)
+	}
+
+	for _, key := range []string{"dedup/b", "dedup/a", "other"} {
+		if err := kv.Put(key, []byte{}); err != nil {
+			t.Fatalf("failed to put %q: %v", key, err)
+		}
+	}
+
+	if swapped, err := kv.CompareAndSwap("dedup/a", []byte{}, []byte("x")); err != nil || !swapped {
+		t.Fatalf("expected the swap of an empty value to succeed, got %v, %v", swapped, err)
+	}
+
+	pairs, err := kv.List("dedup/")
+
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+
+	expected := []KVPair{{Key: "dedup/a", Value: []byte("x")}, {Key: "dedup/b", Value: []byte{}}}
+
+	if !reflect.DeepEqual(pairs, expected) {
+		t.Fatalf("expected pairs %v, got %v", expected, pairs)
+	}
+
+	if err := kv.Delete("dedup/a"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+
+	if err := kv.Delete("dedup/a"); err != nil {
+		t.Fatalf("expected deleting a missing key to succeed, got %v", err)
+	}
+
+	if _, err := kv.Get("dedup/a"); err != ErrKeyNotFound {
+		t.Fatalf("expected %v, got %v", ErrKeyNotFound, err)
+	}
+}
+
+func TestMemoryKV(t *testing.T) {
+	testKV(t, NewMemoryKV())
+}
+
+func TestFileKV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kv")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "kv.json")
+	kv, err := NewFileKV(path)
+
+	if err != nil {
+		t.Fatalf("failed to create file kv: %v", err)
+	}
+
+	testKV(t, kv)
+
+	reopened, err := NewFileKV(path)
+
+	if err != nil {
+		t.Fatalf("failed to reopen file kv: %v", err)
+	}
+
+	if value, err := reopened.Get("cursor"); err != nil || string(value) != "2" {
+		t.Fatalf("expected value %q after reopening, got %q, %v", "2", value, err)
+	}
+}
+
+func TestGRPCStubKV(t *testing.T) {
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		proto.RegisterStubHelperServer(s, &GRPCStubServer{Impl: NewStub(testStub{})})
+	})
+
+	defer server.Stop()
+	defer conn.Close()
+
+	testKV(t, &GRPCStubHelperClient{client: proto.NewStubHelperClient(conn)})
+}
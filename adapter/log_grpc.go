@@ -0,0 +1,282 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LogRecord sends `record` to the host with its fields, time and caller, unless it is below the Level of the host.
+// Stubs set up by SetupStubClient buffer the record and send it in the background, see DefaultLogBufferSize. Records
+// at the fatal or panic level are sent right away, after the records buffered before them, and terminate a plugin in
+// a process of its own once they have been sent, see FatalError.
+func (m *GRPCStubHelperClient) LogRecord(record logger.Record) {
+	if record.Level < m.Level() && !isFatal(record.Level) {
+		return
+	}
+
+	if m.logs != nil && !isFatal(record.Level) {
+		m.logs.add(record)
+
+		return
+	}
+
+	if m.logs != nil {
+		m.logs.flush()
+	}
+
+	m.send(record)
+
+	if m.exits && isFatal(record.Level) {
+		pipelines.flush()
+		terminate(record)
+	}
+}
+
+// DroppedLogs returns the number of log records that the stub dropped because the host could not keep up with them.
+func (m *GRPCStubHelperClient) DroppedLogs() uint64 {
+	if m.logs == nil {
+		return 0
+	}
+
+	return m.logs.droppedTotal()
+}
+
+// send sends `record` to the host right away. Hosts that predate the Log RPC get the formatted record through the
+// log RPC of its level instead.
+func (m *GRPCStubHelperClient) send(record logger.Record) {
+	if atomic.LoadInt32(&m.legacyLog) == 0 {
+		_, err := m.client.Log(context.Background(), logRecordToProto(record))
+
+		if status.Code(err) != codes.Unimplemented {
+			return
+		}
+
+		atomic.StoreInt32(&m.legacyLog, 1)
+	}
+
+	req := &proto.LogRequest{
+		Message: record.String(),
+	}
+
+	switch record.Level {
+	case logger.DebugLevel:
+		m.client.Debugf(context.Background(), req)
+	case logger.WarnLevel:
+		m.client.Warnf(context.Background(), req)
+	case logger.ErrorLevel:
+		m.client.Errorf(context.Background(), req)
+	case logger.FatalLevel:
+		m.client.Fatalf(context.Background(), req)
+	case logger.PanicLevel:
+		m.client.Panicf(context.Background(), req)
+	default:
+		m.client.Printf(context.Background(), req)
+	}
+}
+
+// log logs a printf-style message, recording the caller of the method that called it.
+func (m *GRPCStubHelperClient) log(level logger.Level, format string, v ...interface{}) {
+	m.LogRecord(logger.Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: fmt.Sprintf(format, v...),
+		Caller:  logger.Caller(2),
+	})
+}
+
+func (m *GRPCStubServer) Log(ctx context.Context, req *proto.LogRequest) (*proto.LogResponse, error) {
+	m.write(logRecordFromProto(req))
+
+	return &proto.LogResponse{}, nil
+}
+
+func (m *GRPCStubServer) LogStream(stream proto.StubHelper_LogStreamServer) error {
+	for {
+		batch, err := stream.Recv()
+
+		if err == io.EOF {
+			return stream.SendAndClose(&proto.LogResponse{})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if batch.Dropped > 0 {
+			logger.WriteRecord(m.Impl, droppedLogsRecord(batch.Dropped))
+		}
+
+		for _, req := range batch.Records {
+			m.write(logRecordFromProto(req))
+		}
+	}
+}
+
+// write logs a record of the plugin.
+func (m *GRPCStubServer) write(record logger.Record) {
+	if isFatal(record.Level) {
+		m.fatal(record)
+
+		return
+	}
+
+	logger.WriteRecord(m.Impl, record)
+}
+
+func logLevelToProto(level logger.Level) proto.LogLevel {
+	switch level {
+	case logger.DebugLevel:
+		return proto.LogLevel_DEBUG
+	case logger.WarnLevel:
+		return proto.LogLevel_WARN
+	case logger.ErrorLevel:
+		return proto.LogLevel_ERROR
+	case logger.FatalLevel:
+		return proto.LogLevel_FATAL
+	case logger.PanicLevel:
+		return proto.LogLevel_PANIC
+	}
+
+	return proto.LogLevel_INFO
+}
+
+func logLevelFromProto(level proto.LogLevel) logger.Level {
+	switch level {
+	case proto.LogLevel_DEBUG:
+		return logger.DebugLevel
+	case proto.LogLevel_WARN:
+		return logger.WarnLevel
+	case proto.LogLevel_ERROR:
+		return logger.ErrorLevel
+	case proto.LogLevel_FATAL:
+		return logger.FatalLevel
+	case proto.LogLevel_PANIC:
+		return logger.PanicLevel
+	}
+
+	return logger.InfoLevel
+}
+
+func logRecordToProto(record logger.Record) *proto.LogRequest {
+	req := &proto.LogRequest{
+		Message: record.Message,
+		Level:   logLevelToProto(record.Level),
+		Caller:  record.Caller,
+	}
+
+	if !record.Time.IsZero() {
+		req.Time, _ = ptypes.TimestampProto(record.Time)
+	}
+
+	for _, field := range record.Fields {
+		req.Fields = append(req.Fields, &proto.LogField{
+			Key:   field.Key,
+			Value: fieldValueToProto(field.Value),
+		})
+	}
+
+	return req
+}
+
+func logRecordFromProto(req *proto.LogRequest) logger.Record {
+	record := logger.Record{
+		Level:   logLevelFromProto(req.Level),
+		Message: req.Message,
+		Caller:  req.Caller,
+	}
+
+	if req.Time != nil {
+		record.Time, _ = ptypes.Timestamp(req.Time)
+	}
+
+	for _, field := range req.Fields {
+		record.Fields = append(record.Fields, logger.Field{
+			Key:   field.Key,
+			Value: fieldValueFromProto(field.Value),
+		})
+	}
+
+	return record
+}
+
+// fieldValueToProto converts the value of a log field. Values of types that have no attribute kind are formatted with
+// fmt.Sprint.
+func fieldValueToProto(value interface{}) *proto.AttributeValue {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case AttributeValue:
+		return attributeToProto(v)
+	case string:
+		return attributeToProto(StringAttr(v))
+	case bool:
+		return attributeToProto(BoolAttr(v))
+	case int:
+		return attributeToProto(IntAttr(int64(v)))
+	case int8:
+		return attributeToProto(IntAttr(int64(v)))
+	case int16:
+		return attributeToProto(IntAttr(int64(v)))
+	case int32:
+		return attributeToProto(IntAttr(int64(v)))
+	case int64:
+		return attributeToProto(IntAttr(v))
+	case uint:
+		return attributeToProto(IntAttr(int64(v)))
+	case uint8:
+		return attributeToProto(IntAttr(int64(v)))
+	case uint16:
+		return attributeToProto(IntAttr(int64(v)))
+	case uint32:
+		return attributeToProto(IntAttr(int64(v)))
+	case uint64:
+		return attributeToProto(IntAttr(int64(v)))
+	case float32:
+		return attributeToProto(DoubleAttr(float64(v)))
+	case float64:
+		return attributeToProto(DoubleAttr(v))
+	case []byte:
+		return attributeToProto(BytesAttr(v))
+	case time.Time:
+		return attributeToProto(TimeAttr(v))
+	case error:
+		return attributeToProto(StringAttr(v.Error()))
+	}
+
+	return attributeToProto(StringAttr(fmt.Sprint(value)))
+}
+
+// fieldValueFromProto converts the value of a log field to a string, int64, float64, bool, []byte, time.Time or nil.
+func fieldValueFromProto(value *proto.AttributeValue) interface{} {
+	v, ok := attributeFromProto(value)
+
+	if !ok {
+		return nil
+	}
+
+	switch v.Kind() {
+	case StringAttribute:
+		return v.s
+	case IntAttribute:
+		return v.i
+	case DoubleAttribute:
+		return v.d
+	case BoolAttribute:
+		return v.b
+	case BytesAttribute:
+		return v.raw
+	case TimeAttribute:
+		return v.t
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package adapter
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordLogger sends every record on its channel.
+type recordLogger struct {
+	testStub
+	records chan logger.Record
+}
+
+func (l *recordLogger) LogRecord(record logger.Record) {
+	l.records <- record
+}
+
+// legacyLogClient is the stub client of a host that predates the Log RPC.
+type legacyLogClient struct {
+	proto.StubHelperClient
+}
+
+func (legacyLogClient) Log(ctx context.Context, in *proto.LogRequest, opts ...grpc.CallOption) (*proto.LogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method Log")
+}
+
+func TestGRPCStubStructuredLog(t *testing.T) {
+	log := &recordLogger{records: make(chan logger.Record, 2)}
+	host := NewStub(log, WithSecrets(MapSecrets{"token": "s3cr3t"}))
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		proto.RegisterStubHelperServer(s, &GRPCStubServer{Impl: host})
+	})
+
+	defer server.Stop()
+	defer conn.Close()
+
+	stub := &GRPCStubHelperClient{client: proto.NewStubHelperClient(conn)}
+	stub.Secret("token")
+
+	logger.NewStructured(stub).With("table", "orders").Log(logger.WarnLevel, "polled", "count", 3, "token", "s3cr3t")
+
+	record := <-log.records
+
+	if record.Level != logger.WarnLevel || record.Message != "polled" {
+		t.Fatalf("expected a warning %q, got %+v", "polled", record)
+	}
+
+	expected := []logger.Field{
+		{Key: "table", Value: "orders"},
+		{Key: "count", Value: int64(3)},
+		{Key: "token", Value: "[REDACTED]"},
+	}
+
+	if !reflect.DeepEqual(record.Fields, expected) {
+		t.Fatalf("expected fields %v, got %v", expected, record.Fields)
+	}
+
+	if record.Time.IsZero() || !strings.HasPrefix(record.Caller, "adapter/log_grpc_test.go:") {
+		t.Fatalf("expected the time and caller of the record, got %v and %q", record.Time, record.Caller)
+	}
+
+	stub.Printf("done")
+
+	if record := <-log.records; record.Message != "done" || !strings.HasPrefix(record.Caller, "adapter/log_grpc_test.go:") {
+		t.Fatalf("expected Printf to log a record with its caller, got %+v", record)
+	}
+}
+
+func TestGRPCStubStructuredLogLegacyHost(t *testing.T) {
+	log := &logStub{logs: make(chan string, 1)}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		proto.RegisterStubHelperServer(s, &GRPCStubServer{Impl: NewStub(log)})
+	})
+
+	defer server.Stop()
+	defer conn.Close()
+
+	stub := &GRPCStubHelperClient{client: legacyLogClient{proto.NewStubHelperClient(conn)}}
+
+	logger.NewStructured(stub).Log(logger.InfoLevel, "polled", "count", 3)
+
+	if expected, log := "polled count=3", <-log.logs; log != expected {
+		t.Fatalf("expected log %q, got %q", expected, log)
+	}
+}
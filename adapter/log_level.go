@@ -0,0 +1,153 @@
+package adapter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+)
+
+// logLevelPollInterval is how often a stub server checks the level of its stub for changes, until the level of the
+// plugin instance is set.
+const logLevelPollInterval = time.Second
+
+// logLevel is the log level of a plugin instance, which the stub servers of the instance push to the plugin. Until it
+// is set, it is the level of the stub that the host serves, see logger.LevelOf.
+type logLevel struct {
+	mu       sync.Mutex
+	level    logger.Level
+	set      bool
+	watchers map[chan logger.Level]struct{}
+}
+
+// get returns the log level, falling back to the level of `stub`.
+func (l *logLevel) get(stub Stub) logger.Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.set {
+		return l.level
+	}
+
+	return logger.LevelOf(stub)
+}
+
+// update sets the log level and pushes it to every watcher.
+func (l *logLevel) update(level logger.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level, l.set = level, true
+
+	for watcher := range l.watchers {
+		// watchers only need the latest level, so a level they haven't received yet is replaced
+		select {
+		case <-watcher:
+		default:
+		}
+
+		watcher <- level
+	}
+}
+
+// watch returns a channel that receives the current log level and every later one, until cancel is called.
+func (l *logLevel) watch(stub Stub) (levels <-chan logger.Level, cancel func()) {
+	watcher := make(chan logger.Level, 1)
+	watcher <- l.get(stub)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.watchers == nil {
+		l.watchers = make(map[chan logger.Level]struct{})
+	}
+
+	l.watchers[watcher] = struct{}{}
+
+	return watcher, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		delete(l.watchers, watcher)
+	}
+}
+
+func (m *GRPCStubServer) WatchLogLevel(req *proto.WatchLogLevelRequest, stream proto.StubHelper_WatchLogLevelServer) error {
+	level := m.level
+
+	if level == nil {
+		level = &logLevel{}
+	}
+
+	levels, cancel := level.watch(m.Impl)
+	defer cancel()
+
+	// the level of the stub may change at any time, so it is polled rather than only pushed when it is set
+	ticker := time.NewTicker(logLevelPollInterval)
+	defer ticker.Stop()
+
+	var sent logger.Level
+
+	for {
+		select {
+		case l := <-levels:
+			sent = l
+		case <-ticker.C:
+			l := level.get(m.Impl)
+
+			if l == sent {
+				continue
+			}
+
+			sent = l
+		case <-stream.Context().Done():
+			return nil
+		}
+
+		if err := stream.Send(&proto.LogLevelUpdate{Level: logLevelToProto(sent)}); err != nil {
+			return err
+		}
+	}
+}
+
+// watchLogLevel receives the log level of the host before it returns, and follows its changes in the background
+// until ctx is done. Stubs of hosts that predate WatchLogLevel send every record.
+func (m *GRPCStubHelperClient) watchLogLevel(ctx context.Context) {
+	stream, err := m.client.WatchLogLevel(ctx, &proto.WatchLogLevelRequest{})
+
+	if err != nil {
+		return
+	}
+
+	update, err := stream.Recv()
+
+	if err != nil {
+		return
+	}
+
+	m.setLevel(logLevelFromProto(update.Level))
+
+	go func() {
+		for {
+			update, err := stream.Recv()
+
+			if err != nil {
+				return
+			}
+
+			m.setLevel(logLevelFromProto(update.Level))
+		}
+	}()
+}
+
+func (m *GRPCStubHelperClient) setLevel(level logger.Level) {
+	atomic.StoreInt32(&m.level, int32(level))
+}
+
+// Level returns the lowest level that the host logs. The stub drops the records below it without sending them.
+func (m *GRPCStubHelperClient) Level() logger.Level {
+	return logger.Level(atomic.LoadInt32(&m.level))
+}
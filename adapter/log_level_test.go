@@ -0,0 +1,118 @@
+package adapter
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/unchainio/interfaces/logger"
+)
+
+// levelLogger is a recordLogger that only logs the records at or above its level.
+type levelLogger struct {
+	*recordLogger
+	level logger.Level
+}
+
+func (l levelLogger) Level() logger.Level {
+	return l.level
+}
+
+// levelEndpoint logs at the debug and warn levels at Init, and keeps the stub that it was initialized with.
+type levelEndpoint struct {
+	queueEndpoint
+	stub Stub
+}
+
+func (e *levelEndpoint) Init(stub Stub, config []byte) error {
+	e.stub = stub
+
+	stub.Debugf("dropped")
+	stub.Warnf("kept")
+
+	return nil
+}
+
+func TestGRPCStubLogLevel(t *testing.T) {
+	log := levelLogger{recordLogger: &recordLogger{records: make(chan logger.Record, 2)}, level: logger.WarnLevel}
+	impl := &levelEndpoint{}
+
+	endpoint := dispenseEndpoint(t, impl)
+
+	if err := endpoint.Init(NewStub(log), nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	if record := <-log.records; record.Message != "kept" {
+		t.Fatalf("expected the plugin to drop the debug record, got %+v", record)
+	}
+
+	stub := impl.stub.(*GRPCStubHelperClient)
+
+	if level := stub.Level(); level != logger.WarnLevel {
+		t.Fatalf("expected the plugin to get the level of the host at Init, got %v", level)
+	}
+
+	endpoint.SetLogLevel(logger.DebugLevel)
+
+	for deadline := time.Now().Add(5 * time.Second); stub.Level() != logger.DebugLevel; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the new level to be pushed to the plugin, got %v", stub.Level())
+		}
+	}
+
+	stub.Debugf("debugging")
+	stub.logs.flush()
+
+	if record := <-log.records; record.Message != "debugging" {
+		t.Fatalf("expected the debug record once the level has been lowered, got %+v", record)
+	}
+}
+
+func TestLogLevelWatch(t *testing.T) {
+	var level logLevel
+
+	levels, cancel := level.watch(NewStub(levelLogger{level: logger.ErrorLevel}))
+	defer cancel()
+
+	if l := <-levels; l != logger.ErrorLevel {
+		t.Fatalf("expected the level of the stub, got %v", l)
+	}
+
+	level.update(logger.InfoLevel)
+	level.update(logger.DebugLevel)
+
+	if l := <-levels; l != logger.DebugLevel {
+		t.Fatalf("expected only the latest level, got %v", l)
+	}
+}
+
+// changingLevelLogger is a recordLogger whose level can be changed while it is in use.
+type changingLevelLogger struct {
+	*recordLogger
+	level int32
+}
+
+func (l *changingLevelLogger) Level() logger.Level {
+	return logger.Level(atomic.LoadInt32(&l.level))
+}
+
+func TestGRPCStubLogLevelFollowsHost(t *testing.T) {
+	log := &changingLevelLogger{recordLogger: &recordLogger{records: make(chan logger.Record, 2)}, level: int32(logger.WarnLevel)}
+	impl := &levelEndpoint{}
+
+	endpoint := dispenseEndpoint(t, impl)
+
+	if err := endpoint.Init(NewStub(log), nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	stub := impl.stub.(*GRPCStubHelperClient)
+	atomic.StoreInt32(&log.level, int32(logger.DebugLevel))
+
+	for deadline := time.Now().Add(5 * time.Second); stub.Level() != logger.DebugLevel; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the new level of the host logger to be pushed to the plugin, got %v", stub.Level())
+		}
+	}
+}
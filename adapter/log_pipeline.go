@@ -0,0 +1,339 @@
+package adapter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultLogBufferSize is the number of log records that a plugin buffers while it sends them to the host. Once the
+// buffer is full, the records of the lowest level are dropped first.
+const DefaultLogBufferSize = 1024
+
+// logBatchSize is the largest number of records sent to the host in a single LogBatch.
+const logBatchSize = 64
+
+// logPipeline sends the log records of a plugin to the host in the background, in batches over the LogStream RPC.
+// Hosts that predate LogStream get every record through `send` instead.
+type logPipeline struct {
+	client proto.StubHelperClient
+	send   func(record logger.Record)
+	size   int
+
+	mu      sync.Mutex
+	records []logger.Record
+	closed  bool
+
+	// dropped is the number of records dropped since the last batch, and total the number dropped since the start
+	dropped uint64
+	total   uint64
+
+	wake    chan struct{}
+	flushes chan chan struct{}
+	quit    chan struct{}
+	done    chan struct{}
+
+	// only used by run; sent is the number of records sent on the stream that the host hasn't acknowledged yet
+	stream    proto.StubHelper_LogStreamClient
+	sent      uint64
+	confirmed bool
+	legacy    bool
+}
+
+func newLogPipeline(client proto.StubHelperClient, send func(record logger.Record), size int) *logPipeline {
+	p := &logPipeline{
+		client:  client,
+		send:    send,
+		size:    size,
+		wake:    make(chan struct{}, 1),
+		flushes: make(chan chan struct{}),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	pipelines.add(p)
+	go p.run()
+
+	return p
+}
+
+// add buffers `record`. If the buffer is full, the oldest record of the lowest level is dropped, which is `record`
+// itself if no buffered record has a lower level.
+func (p *logPipeline) add(record logger.Record) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		p.drop(1)
+
+		return
+	}
+
+	if len(p.records) >= p.size {
+		lowest := 0
+
+		for i, r := range p.records {
+			if r.Level < p.records[lowest].Level {
+				lowest = i
+			}
+		}
+
+		p.drop(1)
+
+		if record.Level <= p.records[lowest].Level {
+			return
+		}
+
+		p.records = append(p.records[:lowest], p.records[lowest+1:]...)
+	}
+
+	p.records = append(p.records, record)
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// drop counts `n` dropped records. It must be called with mu held.
+func (p *logPipeline) drop(n uint64) {
+	p.dropped += n
+	p.total += n
+}
+
+// droppedTotal returns the number of records dropped since the pipeline started.
+func (p *logPipeline) droppedTotal() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.total
+}
+
+// flush returns once every record added before it has been processed by the host.
+func (p *logPipeline) flush() {
+	done := make(chan struct{})
+
+	select {
+	case p.flushes <- done:
+		<-done
+	case <-p.done:
+	}
+}
+
+// close flushes the buffered records and stops the pipeline. Records added after close are dropped.
+func (p *logPipeline) close() {
+	p.mu.Lock()
+
+	if p.closed {
+		p.mu.Unlock()
+
+		return
+	}
+
+	p.closed = true
+	p.mu.Unlock()
+
+	close(p.quit)
+	<-p.done
+
+	pipelines.remove(p)
+}
+
+func (p *logPipeline) run() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.wake:
+			p.sendBuffered()
+			p.finish()
+		case done := <-p.flushes:
+			p.sendBuffered()
+			p.finish()
+			close(done)
+		case <-p.quit:
+			p.sendBuffered()
+			p.finish()
+
+			return
+		}
+	}
+}
+
+// take removes the next batch of records from the buffer.
+func (p *logPipeline) take() (records []logger.Record, dropped uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.records)
+
+	if n > logBatchSize {
+		n = logBatchSize
+	}
+
+	records = append(records, p.records[:n]...)
+	p.records = append(p.records[:0], p.records[n:]...)
+
+	dropped, p.dropped = p.dropped, 0
+
+	return records, dropped
+}
+
+// sendBuffered sends the buffered records. They are only delivered once the host has acknowledged them by ending the
+// stream, see finish.
+func (p *logPipeline) sendBuffered() {
+	for {
+		records, dropped := p.take()
+
+		if len(records) == 0 && dropped == 0 {
+			return
+		}
+
+		// the records that are still buffered are sent with the next batch, rather than retried right away
+		if !p.sendBatch(records, dropped) {
+			return
+		}
+	}
+}
+
+// sendBatch sends `records`, and reports whether they were sent.
+func (p *logPipeline) sendBatch(records []logger.Record, dropped uint64) bool {
+	if p.legacy {
+		if dropped > 0 {
+			p.send(droppedLogsRecord(dropped))
+		}
+
+		for _, record := range records {
+			p.send(record)
+		}
+
+		return true
+	}
+
+	batch := &proto.LogBatch{Dropped: dropped}
+
+	for _, record := range records {
+		batch.Records = append(batch.Records, logRecordToProto(record))
+	}
+
+	if p.stream == nil {
+		stream, err := p.client.LogStream(context.Background())
+
+		if err != nil {
+			return p.fail(err, records, dropped)
+		}
+
+		p.stream = stream
+	}
+
+	err := p.stream.Send(batch)
+
+	if err == nil && p.confirmed {
+		p.sent += uint64(len(records))
+
+		return true
+	}
+
+	// a failed Send means that the stream has ended, and CloseAndRecv returns the reason. The first stream is also
+	// ended right away, to find out whether the host supports LogStream at all.
+	if err := p.finish(); err != nil {
+		return p.fail(err, records, dropped)
+	}
+
+	p.confirmed = true
+
+	return true
+}
+
+// finish ends the current stream, once the host has processed every batch sent on it. If the stream broke instead,
+// the records sent on it are counted as dropped.
+func (p *logPipeline) finish() error {
+	if p.stream == nil {
+		return nil
+	}
+
+	_, err := p.stream.CloseAndRecv()
+	sent := p.sent
+	p.stream, p.sent = nil, 0
+
+	if err != nil && sent > 0 {
+		p.mu.Lock()
+		p.drop(sent)
+		p.mu.Unlock()
+	}
+
+	return err
+}
+
+// fail handles a batch that could not be sent: it is sent again record by record if the host predates LogStream,
+// and counted as dropped otherwise, so that the host is told about them with the next batch. It reports whether the
+// records have been sent after all.
+func (p *logPipeline) fail(err error, records []logger.Record, dropped uint64) bool {
+	if status.Code(err) == codes.Unimplemented {
+		p.legacy = true
+
+		return p.sendBatch(records, dropped)
+	}
+
+	p.mu.Lock()
+	p.drop(uint64(len(records)))
+	// the records dropped before the batch have already been counted in total
+	p.dropped += dropped
+	p.mu.Unlock()
+
+	return false
+}
+
+// droppedLogsRecord is the warning that the host logs for `dropped` records that a plugin dropped.
+func droppedLogsRecord(dropped uint64) logger.Record {
+	return logger.Record{
+		Time:    time.Now(),
+		Level:   logger.WarnLevel,
+		Message: "the plugin dropped log records",
+		Fields:  []logger.Field{{Key: "dropped", Value: int64(dropped)}},
+	}
+}
+
+// pipelines are the log pipelines of the plugin process, which are flushed before it exits.
+var pipelines = &pipelineSet{set: make(map[*logPipeline]struct{})}
+
+type pipelineSet struct {
+	mu  sync.Mutex
+	set map[*logPipeline]struct{}
+}
+
+func (s *pipelineSet) add(p *logPipeline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set[p] = struct{}{}
+}
+
+func (s *pipelineSet) remove(p *logPipeline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.set, p)
+}
+
+// flush flushes every log pipeline of the plugin process.
+func (s *pipelineSet) flush() {
+	s.mu.Lock()
+
+	var all []*logPipeline
+
+	for p := range s.set {
+		all = append(all, p)
+	}
+
+	s.mu.Unlock()
+
+	for _, p := range all {
+		p.flush()
+	}
+}
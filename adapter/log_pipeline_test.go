@@ -0,0 +1,230 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countingStubServer counts the records that it receives through the unary Log RPC.
+type countingStubServer struct {
+	*GRPCStubServer
+	unary int32
+}
+
+func (s *countingStubServer) Log(ctx context.Context, req *proto.LogRequest) (*proto.LogResponse, error) {
+	atomic.AddInt32(&s.unary, 1)
+
+	return s.GRPCStubServer.Log(ctx, req)
+}
+
+// legacyLogStreamClient is the stub client of a host that predates the LogStream RPC.
+type legacyLogStreamClient struct {
+	proto.StubHelperClient
+}
+
+func (legacyLogStreamClient) LogStream(ctx context.Context, opts ...grpc.CallOption) (proto.StubHelper_LogStreamClient, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method LogStream")
+}
+
+func dialStubServer(t *testing.T, server proto.StubHelperServer) (proto.StubHelperClient, func()) {
+	conn, s := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		proto.RegisterStubHelperServer(s, server)
+	})
+
+	return proto.NewStubHelperClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestLogPipelineBatches(t *testing.T) {
+	log := &recordLogger{records: make(chan logger.Record, 200)}
+	server := &countingStubServer{GRPCStubServer: &GRPCStubServer{Impl: NewStub(log)}}
+
+	client, stop := dialStubServer(t, server)
+	defer stop()
+
+	stub := &GRPCStubHelperClient{client: client}
+	stub.logs = newLogPipeline(client, stub.send, DefaultLogBufferSize)
+
+	for i := 0; i < 200; i++ {
+		stub.Debugf("record %d", i)
+	}
+
+	stub.logs.close()
+
+	for i := 0; i < 200; i++ {
+		if expected, record := fmt.Sprintf("record %d", i), <-log.records; record.Message != expected {
+			t.Fatalf("expected record %q, got %+v", expected, record)
+		}
+	}
+
+	if unary := atomic.LoadInt32(&server.unary); unary != 0 {
+		t.Fatalf("expected the records to be streamed, got %d unary Log calls", unary)
+	}
+
+	if dropped := stub.DroppedLogs(); dropped != 0 {
+		t.Fatalf("expected no dropped records, got %d", dropped)
+	}
+}
+
+func TestLogPipelineDropsLowestLevel(t *testing.T) {
+	log := &recordLogger{records: make(chan logger.Record, 4)}
+
+	client, stop := dialStubServer(t, &GRPCStubServer{Impl: NewStub(log)})
+	defer stop()
+
+	stub := &GRPCStubHelperClient{client: client}
+
+	// a pipeline that isn't running yet stands in for a host that can't keep up
+	p := &logPipeline{
+		client:  client,
+		send:    stub.send,
+		size:    3,
+		wake:    make(chan struct{}, 1),
+		flushes: make(chan chan struct{}),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for _, record := range []logger.Record{
+		{Level: logger.InfoLevel, Message: "info"},
+		{Level: logger.DebugLevel, Message: "debug"},
+		{Level: logger.ErrorLevel, Message: "error"},
+		{Level: logger.WarnLevel, Message: "warn"},
+		{Level: logger.DebugLevel, Message: "another debug"},
+	} {
+		p.add(record)
+	}
+
+	if total := p.droppedTotal(); total != 2 {
+		t.Fatalf("expected 2 dropped records, got %d", total)
+	}
+
+	go p.run()
+	p.close()
+
+	dropped := <-log.records
+
+	if dropped.Level != logger.WarnLevel || len(dropped.Fields) != 1 || dropped.Fields[0].Value != int64(2) {
+		t.Fatalf("expected the host to log a warning about 2 dropped records, got %+v", dropped)
+	}
+
+	for _, expected := range []string{"info", "error", "warn"} {
+		if record := <-log.records; record.Message != expected {
+			t.Fatalf("expected record %q, got %+v", expected, record)
+		}
+	}
+}
+
+func TestLogPipelineCountsFailedBatches(t *testing.T) {
+	p := &logPipeline{}
+
+	records := []logger.Record{{Message: "a"}, {Message: "b"}}
+
+	if p.fail(status.Error(codes.Unavailable, "gone"), records, 3) {
+		t.Fatalf("expected the batch not to be sent")
+	}
+
+	if p.dropped != 5 || p.total != 2 {
+		t.Fatalf("expected the lost records to be reported with the next batch, got %d dropped and %d in total", p.dropped, p.total)
+	}
+}
+
+func TestLogPipelineLegacyHost(t *testing.T) {
+	log := &logStub{logs: make(chan string, 2)}
+
+	client, stop := dialStubServer(t, &GRPCStubServer{Impl: NewStub(log)})
+	defer stop()
+
+	stub := &GRPCStubHelperClient{client: legacyLogStreamClient{client}}
+	stub.logs = newLogPipeline(stub.client, stub.send, DefaultLogBufferSize)
+
+	stub.Printf("first")
+	stub.Printf("second")
+	stub.logs.close()
+
+	for _, expected := range []string{"first", "second"} {
+		if log := <-log.logs; log != expected {
+			t.Fatalf("expected log %q, got %q", expected, log)
+		}
+	}
+}
+
+func TestLogPipelineFlushesBeforeFatal(t *testing.T) {
+	log := &recordLogger{records: make(chan logger.Record, 2)}
+
+	client, stop := dialStubServer(t, &GRPCStubServer{Impl: NewStub(log)})
+	defer stop()
+
+	stub := &GRPCStubHelperClient{client: client}
+	stub.logs = newLogPipeline(client, stub.send, DefaultLogBufferSize)
+	defer stub.logs.close()
+
+	stub.Printf("connecting")
+	stub.Fatalf("connection refused")
+
+	if record := <-log.records; record.Message != "connecting" {
+		t.Fatalf("expected the buffered record first, got %+v", record)
+	}
+
+	if record := <-log.records; record.Message != "connection refused" || record.Level != logger.ErrorLevel {
+		t.Fatalf("expected the fatal record as an error, got %+v", record)
+	}
+}
+
+// breakingLogStreamClient is the stub client of a host whose log streams break after the first one, once the batches
+// sent on them have been accepted.
+type breakingLogStreamClient struct {
+	proto.StubHelperClient
+	streams int32
+}
+
+func (c *breakingLogStreamClient) LogStream(ctx context.Context, opts ...grpc.CallOption) (proto.StubHelper_LogStreamClient, error) {
+	return &breakingLogStream{broken: atomic.AddInt32(&c.streams, 1) > 1}, nil
+}
+
+type breakingLogStream struct {
+	grpc.ClientStream
+	broken bool
+}
+
+func (s *breakingLogStream) Send(batch *proto.LogBatch) error {
+	return nil
+}
+
+func (s *breakingLogStream) CloseAndRecv() (*proto.LogResponse, error) {
+	if s.broken {
+		return nil, status.Error(codes.Unavailable, "transport is closing")
+	}
+
+	return &proto.LogResponse{}, nil
+}
+
+func TestLogPipelineCountsUnacknowledgedBatches(t *testing.T) {
+	stub := &GRPCStubHelperClient{client: &breakingLogStreamClient{}}
+	stub.logs = newLogPipeline(stub.client, stub.send, DefaultLogBufferSize)
+	defer stub.logs.close()
+
+	stub.Printf("confirmed")
+	stub.logs.flush()
+
+	for i := 0; i < 3; i++ {
+		stub.Printf("lost %d", i)
+	}
+
+	stub.logs.flush()
+
+	if dropped := stub.DroppedLogs(); dropped != 3 {
+		t.Fatalf("expected the records sent on the broken stream to be dropped, got %d", dropped)
+	}
+}
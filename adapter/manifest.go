@@ -0,0 +1,94 @@
+package adapter
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+)
+
+// Kind is the kind of plugin: an endpoint or an action.
+type Kind string
+
+const (
+	KindEndpoint Kind = "endpoint"
+	KindAction   Kind = "action"
+)
+
+// DescribeFlag makes StartEndpoint and StartAction print the manifest of the plugin as JSON to stdout and return,
+// instead of serving the plugin, so that hosts can tell plugin binaries apart without loading them.
+const DescribeFlag = "--describe"
+
+// Manifest is what a plugin binary declares about itself. Plugins declare it with WithManifest; Kind and Capabilities
+// are filled in by StartEndpoint and StartAction.
+type Manifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Kind        Kind   `json:"kind"`
+	Description string `json:"description,omitempty"`
+
+	// ConfigSchema is the JSON schema of the config that is passed to Init
+	ConfigSchema json.RawMessage `json:"configSchema,omitempty"`
+
+	Capabilities []Capability `json:"capabilities"`
+}
+
+// WithManifest declares the manifest of the plugin.
+func WithManifest(manifest Manifest) StartOption {
+	return func(c *startConfig) {
+		c.manifest = manifest
+	}
+}
+
+// describeRequested reports whether the plugin binary has been launched with DescribeFlag.
+func describeRequested() bool {
+	for _, arg := range os.Args[1:] {
+		if arg == DescribeFlag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeManifest writes `manifest` as JSON, completed with `kind` and the capabilities of `d`.
+func writeManifest(w io.Writer, manifest Manifest, kind Kind, d *Description) error {
+	manifest.Kind = kind
+	manifest.Capabilities = d.Capabilities
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(manifest)
+}
+
+func manifestToProto(manifest Manifest) *proto.Manifest {
+	return &proto.Manifest{
+		Name:         manifest.Name,
+		Version:      manifest.Version,
+		Kind:         string(manifest.Kind),
+		Description:  manifest.Description,
+		ConfigSchema: manifest.ConfigSchema,
+	}
+}
+
+func manifestFromProto(manifest *proto.Manifest, capabilities []Capability) *Manifest {
+	if manifest == nil {
+		return nil
+	}
+
+	m := &Manifest{
+		Name:         manifest.Name,
+		Version:      manifest.Version,
+		Kind:         Kind(manifest.Kind),
+		Description:  manifest.Description,
+		Capabilities: capabilities,
+	}
+
+	if len(manifest.ConfigSchema) > 0 {
+		m.ConfigSchema = manifest.ConfigSchema
+	}
+
+	return m
+}
@@ -19,27 +19,67 @@ type TaggedMessage struct {
 }
 
 type Message struct {
-	ID         string
-	Body       []byte
-	Attributes map[string]bool
+	ID      string
+	Body    []byte
+	Headers map[string]string
+
+	// Attributes are typed; plugins built against versions of this package without typed attributes only see the
+	// BoolAttribute ones
+	Attributes map[string]AttributeValue
 }
 
 type MessageOpts struct {
 	tag uint64
+	id  string
 }
 
+// NewMessage constructs a new message with a newly generated ID
 func NewMessage(body []byte) *Message {
 	return &Message{
+		ID:         newMessageID(),
 		Body:       body,
-		Attributes: make(map[string]bool),
+		Headers:    make(map[string]string),
+		Attributes: make(map[string]AttributeValue),
+	}
+}
+
+// cloneMessage returns a deep copy of `message`.
+func cloneMessage(message *Message) *Message {
+	if message == nil {
+		return nil
+	}
+
+	clone := &Message{
+		ID:         message.ID,
+		Headers:    make(map[string]string, len(message.Headers)),
+		Attributes: make(map[string]AttributeValue, len(message.Attributes)),
+	}
+
+	if message.Body != nil {
+		clone.Body = append([]byte{}, message.Body...)
+	}
+
+	for key, value := range message.Headers {
+		clone.Headers[key] = value
 	}
+
+	for key, value := range message.Attributes {
+		if raw, ok := value.BytesValue(); ok && raw != nil {
+			value = BytesAttr(append([]byte{}, raw...))
+		}
+
+		clone.Attributes[key] = value
+	}
+
+	return clone
 }
 
 var defaultOpts = MessageOpts{}
 
 type MessageOptsFunc func(opt *MessageOpts)
 
-// NewMessage constructs a new message with a random tag unless a custom one is specified via WithTag(tag uint64)
+// NewTaggedMessage constructs a new message with a random tag unless a custom one is specified via WithTag(tag uint64),
+// and a newly generated ID unless a custom one is specified via WithID(id string)
 func NewTaggedMessage(body []byte, optFuncs ...MessageOptsFunc) *TaggedMessage {
 	opts := defaultOpts
 
@@ -51,9 +91,15 @@ func NewTaggedMessage(body []byte, optFuncs ...MessageOptsFunc) *TaggedMessage {
 		opts.tag = globalCounter.Add(1)
 	}
 
+	message := NewMessage(body)
+
+	if opts.id != "" {
+		message.ID = opts.id
+	}
+
 	return &TaggedMessage{
 		Tag:     opts.tag,
-		Message: NewMessage(body),
+		Message: message,
 	}
 }
 
@@ -61,6 +107,25 @@ func randomTag() uint64 {
 	return rand.Uint64()
 }
 
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newMessageID returns a ULID: a 48 bit millisecond timestamp followed by 80 random bits, encoded as 26 characters of
+// Crockford's base32. IDs generated in different milliseconds sort in the order they were generated.
+func newMessageID() string {
+	hi := uint64(time.Now().UnixNano()/int64(time.Millisecond))<<16 | uint64(rand.Intn(1<<16))
+	lo := rand.Uint64()
+
+	var id [26]byte
+
+	for i := len(id) - 1; i >= 0; i-- {
+		id[i] = crockfordBase32[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(id[:])
+}
+
 func WithTag(tag uint64) MessageOptsFunc {
 	return func(opts *MessageOpts) {
 		opts.tag = tag
@@ -72,3 +137,11 @@ func WithRandomTag() MessageOptsFunc {
 		opts.tag = randomTag()
 	}
 }
+
+// WithID sets the ID of the message, e.g. to the ID the message has in the system it was received from, so that
+// redeliveries of the same message can be recognized and deduplicated
+func WithID(id string) MessageOptsFunc {
+	return func(opts *MessageOpts) {
+		opts.id = id
+	}
+}
@@ -0,0 +1,186 @@
+package adapter
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/unchainio/interfaces/adapter/proto"
+)
+
+// messageToProto converts a message to its wire representation. Every attribute goes into the typed attributes, and
+// the bool-valued ones also into the legacy attributes, which are all that older plugins read.
+func messageToProto(message *Message) *proto.AdapterMessage {
+	if message == nil {
+		return nil
+	}
+
+	m := &proto.AdapterMessage{
+		Id:      message.ID,
+		Body:    message.Body,
+		Headers: message.Headers,
+	}
+
+	for key, value := range message.Attributes {
+		typed := attributeToProto(value)
+
+		if typed == nil {
+			continue
+		}
+
+		if m.TypedAttributes == nil {
+			m.TypedAttributes = make(map[string]*proto.AttributeValue)
+		}
+
+		m.TypedAttributes[key] = typed
+
+		if b, ok := value.BoolValue(); ok {
+			if m.Attributes == nil {
+				m.Attributes = make(map[string]bool)
+			}
+
+			m.Attributes[key] = b
+		}
+	}
+
+	return m
+}
+
+// messageFromProto converts a message from its wire representation. Legacy attributes, which is all that older
+// plugins send, become bool attributes, and are overridden by the typed attributes with the same key.
+func messageFromProto(message *proto.AdapterMessage) *Message {
+	if message == nil {
+		return nil
+	}
+
+	m := &Message{
+		ID:         message.Id,
+		Body:       message.Body,
+		Headers:    message.Headers,
+		Attributes: make(map[string]AttributeValue, len(message.TypedAttributes)),
+	}
+
+	if m.Headers == nil {
+		m.Headers = make(map[string]string)
+	}
+
+	for key, value := range message.Attributes {
+		m.Attributes[key] = BoolAttr(value)
+	}
+
+	for key, value := range message.TypedAttributes {
+		if attribute, ok := attributeFromProto(value); ok {
+			m.Attributes[key] = attribute
+		}
+	}
+
+	return m
+}
+
+// mergeLegacyResponse merges the `response` of a plugin that predates typed attributes into its `request`. Such a
+// plugin only returns the body and the bool attributes, so the ID, the headers and the other attributes of the
+// request are kept, unless the response sets them.
+func mergeLegacyResponse(request *Message, response *Message) *Message {
+	merged := &Message{
+		ID:         request.ID,
+		Body:       response.Body,
+		Headers:    make(map[string]string, len(request.Headers)+len(response.Headers)),
+		Attributes: make(map[string]AttributeValue, len(request.Attributes)+len(response.Attributes)),
+	}
+
+	if response.ID != "" {
+		merged.ID = response.ID
+	}
+
+	for key, value := range request.Headers {
+		merged.Headers[key] = value
+	}
+
+	for key, value := range response.Headers {
+		merged.Headers[key] = value
+	}
+
+	// the bool attributes that the plugin removed stay removed
+	for key, value := range request.Attributes {
+		if value.Kind() != BoolAttribute {
+			merged.Attributes[key] = value
+		}
+	}
+
+	for key, value := range response.Attributes {
+		merged.Attributes[key] = value
+	}
+
+	return merged
+}
+
+func attributeToProto(value AttributeValue) *proto.AttributeValue {
+	switch value.Kind() {
+	case StringAttribute:
+		return &proto.AttributeValue{Value: &proto.AttributeValue_StringValue{StringValue: value.s}}
+	case IntAttribute:
+		return &proto.AttributeValue{Value: &proto.AttributeValue_IntValue{IntValue: value.i}}
+	case DoubleAttribute:
+		return &proto.AttributeValue{Value: &proto.AttributeValue_DoubleValue{DoubleValue: value.d}}
+	case BoolAttribute:
+		return &proto.AttributeValue{Value: &proto.AttributeValue_BoolValue{BoolValue: value.b}}
+	case BytesAttribute:
+		return &proto.AttributeValue{Value: &proto.AttributeValue_BytesValue{BytesValue: value.raw}}
+	case TimeAttribute:
+		ts, err := ptypes.TimestampProto(value.t)
+
+		if err != nil {
+			return nil
+		}
+
+		return &proto.AttributeValue{Value: &proto.AttributeValue_TimestampValue{TimestampValue: ts}}
+	}
+
+	return nil
+}
+
+func attributeFromProto(value *proto.AttributeValue) (AttributeValue, bool) {
+	switch v := value.GetValue().(type) {
+	case *proto.AttributeValue_StringValue:
+		return StringAttr(v.StringValue), true
+	case *proto.AttributeValue_IntValue:
+		return IntAttr(v.IntValue), true
+	case *proto.AttributeValue_DoubleValue:
+		return DoubleAttr(v.DoubleValue), true
+	case *proto.AttributeValue_BoolValue:
+		return BoolAttr(v.BoolValue), true
+	case *proto.AttributeValue_BytesValue:
+		return BytesAttr(v.BytesValue), true
+	case *proto.AttributeValue_TimestampValue:
+		t, err := ptypes.Timestamp(v.TimestampValue)
+
+		if err != nil {
+			return AttributeValue{}, false
+		}
+
+		return TimeAttr(t), true
+	}
+
+	return AttributeValue{}, false
+}
+
+// batchItemToProto converts the outcome of a single message of a batch.
+func batchItemToProto(message *proto.AdapterMessage, err error) *proto.BatchItemResult {
+	item := &proto.BatchItemResult{
+		Message: message,
+	}
+
+	if err != nil {
+		item.Failed = true
+		item.Error = err.Error()
+		item.ErrorDetail = errorToProto(err)
+	}
+
+	return item
+}
+
+// batchItemError returns the error of a single message of a batch, or nil if it succeeded.
+func batchItemError(item *proto.BatchItemResult) error {
+	if item == nil || !item.Failed {
+		return nil
+	}
+
+	return errorFromParts(item.Error, item.ErrorDetail)
+}
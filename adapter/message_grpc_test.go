@@ -0,0 +1,58 @@
+package adapter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/unchainio/interfaces/adapter/proto"
+)
+
+func TestMessageProtoRoundTrip(t *testing.T) {
+	message := NewMessage([]byte("body"))
+	message.Headers["content-type"] = "application/json"
+	message.Attributes["routing-key"] = StringAttr("orders")
+	message.Attributes["attempt"] = IntAttr(3)
+	message.Attributes["amount"] = DoubleAttr(1.5)
+	message.Attributes["processed"] = BoolAttr(true)
+	message.Attributes["signature"] = BytesAttr([]byte{1, 2, 3})
+	message.Attributes["received-at"] = TimeAttr(time.Date(2018, 7, 19, 9, 45, 0, 0, time.UTC))
+
+	data, err := pb.Marshal(messageToProto(message))
+
+	if err != nil {
+		t.Fatalf("failed to marshal message: %v", err)
+	}
+
+	wire := &proto.AdapterMessage{}
+
+	if err := pb.Unmarshal(data, wire); err != nil {
+		t.Fatalf("failed to unmarshal message: %v", err)
+	}
+
+	if expected := map[string]bool{"processed": true}; !reflect.DeepEqual(wire.Attributes, expected) {
+		t.Fatalf("expected legacy attributes %v, got %v", expected, wire.Attributes)
+	}
+
+	if decoded := messageFromProto(wire); !reflect.DeepEqual(decoded, message) {
+		t.Fatalf("expected message %+v, got %+v", message, decoded)
+	}
+}
+
+func TestMessageFromLegacyProto(t *testing.T) {
+	message := messageFromProto(&proto.AdapterMessage{
+		Body:       []byte("body"),
+		Attributes: map[string]bool{"processed": true, "valid": false},
+	})
+
+	expected := map[string]AttributeValue{"processed": BoolAttr(true), "valid": BoolAttr(false)}
+
+	if !reflect.DeepEqual(message.Attributes, expected) {
+		t.Fatalf("expected attributes %v, got %v", expected, message.Attributes)
+	}
+
+	if message.Headers == nil {
+		t.Fatalf("expected the headers of a legacy message to be usable")
+	}
+}
@@ -0,0 +1,403 @@
+package adapter
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Labels are the label names and values of a metric.
+type Labels map[string]string
+
+// Metrics records the metrics of a plugin instance, such as the number of records it polled. Names and labels follow
+// the Prometheus conventions, e.g. "records_polled_total". Samples with invalid names or labels, negative counter
+// increments, and samples of a name that has been used for a metric of another kind are ignored.
+type Metrics interface {
+	// AddCounter adds `delta` to the counter `name`
+	AddCounter(name string, delta float64, labels Labels)
+
+	// SetGauge sets the gauge `name` to `value`
+	SetGauge(name string, value float64, labels Labels)
+
+	// ObserveHistogram records `value` in the histogram `name`, whose buckets are DefaultBuckets
+	ObserveHistogram(name string, value float64, labels Labels)
+}
+
+// HistogramMerger is implemented by the Metrics that can record the observations of a histogram that have already been
+// bucketed, such as the ones that plugins send to the host.
+type HistogramMerger interface {
+	// MergeHistogram records `count` observations that sum to `sum` in the histogram `name`, of which buckets[i] are
+	// up to DefaultBuckets[i]
+	MergeHistogram(name string, buckets []uint64, sum float64, count uint64, labels Labels)
+}
+
+// mergeHistogram merges bucketed observations into `metrics`. Metrics that are no HistogramMerger get every
+// observation at the upper bound of its bucket, and the ones above the last bound at their average. Observations
+// bucketed with other bounds than DefaultBuckets are ignored.
+func mergeHistogram(metrics Metrics, name string, buckets []uint64, sum float64, count uint64, labels Labels) {
+	if merger, ok := metrics.(HistogramMerger); ok {
+		merger.MergeHistogram(name, buckets, sum, count, labels)
+
+		return
+	}
+
+	if len(buckets) != len(DefaultBuckets) || !sort.SliceIsSorted(buckets, func(i, j int) bool { return buckets[i] < buckets[j] }) || buckets[len(buckets)-1] > count {
+		return
+	}
+
+	var observed uint64
+
+	for i, n := range buckets {
+		for ; observed < n; observed++ {
+			metrics.ObserveHistogram(name, DefaultBuckets[i], labels)
+			sum -= DefaultBuckets[i]
+		}
+	}
+
+	for n := observed; n < count; n++ {
+		metrics.ObserveHistogram(name, sum/float64(count-observed), labels)
+	}
+}
+
+// observeBuckets counts `value` in the cumulative `buckets` of DefaultBuckets.
+func observeBuckets(buckets []uint64, value float64) {
+	for i, bound := range DefaultBuckets {
+		if value <= bound {
+			buckets[i]++
+		}
+	}
+}
+
+// DefaultBuckets are the upper bounds of the buckets of every histogram, suited to latencies in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DiscardMetrics is a Metrics that records nothing, which is what stubs record to unless configured with WithMetrics.
+var DiscardMetrics Metrics = discardMetrics{}
+
+type discardMetrics struct{}
+
+func (discardMetrics) AddCounter(name string, delta float64, labels Labels)       {}
+func (discardMetrics) SetGauge(name string, value float64, labels Labels)         {}
+func (discardMetrics) ObserveHistogram(name string, value float64, labels Labels) {}
+
+type metricKind int
+
+const (
+	counterKind metricKind = iota
+	gaugeKind
+	histogramKind
+)
+
+func (k metricKind) String() string {
+	switch k {
+	case counterKind:
+		return "counter"
+	case gaugeKind:
+		return "gauge"
+	}
+
+	return "histogram"
+}
+
+var (
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// MetricsRegistry aggregates metrics and exposes them in the Prometheus text format. It is safe for concurrent use.
+//
+// Hosts aggregate the metrics of every plugin instance apart by giving each instance a stub of its own, whose metrics
+// carry labels that identify it: NewStub(log, WithMetrics(registry.With(Labels{"plugin": "orders"}))).
+type MetricsRegistry struct {
+	mu       sync.Mutex
+	families map[string]*metricFamily
+}
+
+type metricFamily struct {
+	kind   metricKind
+	series map[string]*metricSeries
+}
+
+type metricSeries struct {
+	// value is the value of a counter or gauge, or the sum of the observations of a histogram
+	value float64
+
+	// buckets are the number of observations of a histogram up to each of DefaultBuckets
+	buckets []uint64
+	count   uint64
+}
+
+// NewMetricsRegistry returns an empty registry.
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{families: make(map[string]*metricFamily)}
+}
+
+func (r *MetricsRegistry) AddCounter(name string, delta float64, labels Labels) {
+	if delta < 0 || math.IsNaN(delta) {
+		return
+	}
+
+	r.record(counterKind, name, labels, func(s *metricSeries) {
+		s.value += delta
+	})
+}
+
+func (r *MetricsRegistry) SetGauge(name string, value float64, labels Labels) {
+	r.record(gaugeKind, name, labels, func(s *metricSeries) {
+		s.value = value
+	})
+}
+
+func (r *MetricsRegistry) ObserveHistogram(name string, value float64, labels Labels) {
+	r.record(histogramKind, name, labels, func(s *metricSeries) {
+		if s.buckets == nil {
+			s.buckets = make([]uint64, len(DefaultBuckets))
+		}
+
+		observeBuckets(s.buckets, value)
+		s.value += value
+		s.count++
+	})
+}
+
+// MergeHistogram records observations of the histogram `name` that have already been bucketed, see HistogramMerger.
+// Observations bucketed with other bounds than DefaultBuckets are ignored.
+func (r *MetricsRegistry) MergeHistogram(name string, buckets []uint64, sum float64, count uint64, labels Labels) {
+	if len(buckets) != len(DefaultBuckets) {
+		return
+	}
+
+	r.record(histogramKind, name, labels, func(s *metricSeries) {
+		if s.buckets == nil {
+			s.buckets = make([]uint64, len(DefaultBuckets))
+		}
+
+		for i, n := range buckets {
+			s.buckets[i] += n
+		}
+
+		s.value += sum
+		s.count += count
+	})
+}
+
+func (r *MetricsRegistry) record(kind metricKind, name string, labels Labels, update func(s *metricSeries)) {
+	key, ok := formatLabels(labels)
+
+	if !ok || !metricNamePattern.MatchString(name) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	family, ok := r.families[name]
+
+	if !ok {
+		family = &metricFamily{kind: kind, series: make(map[string]*metricSeries)}
+		r.families[name] = family
+	}
+
+	if family.kind != kind {
+		return
+	}
+
+	series, ok := family.series[key]
+
+	if !ok {
+		series = &metricSeries{}
+		family.series[key] = series
+	}
+
+	update(series)
+}
+
+// Value returns the value of the counter or gauge `name` with exactly `labels`, or the number of observations of the
+// histogram `name`. It returns 0 if nothing has been recorded.
+func (r *MetricsRegistry) Value(name string, labels Labels) float64 {
+	key, _ := formatLabels(labels)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	family, ok := r.families[name]
+
+	if !ok {
+		return 0
+	}
+
+	series, ok := family.series[key]
+
+	if !ok {
+		return 0
+	}
+
+	if family.kind == histogramKind {
+		return float64(series.count)
+	}
+
+	return series.value
+}
+
+// With returns a Metrics that records to the registry with `labels` added to the labels of every sample. They take
+// precedence over labels of the same name, so that plugins can't record metrics as another instance.
+func (r *MetricsRegistry) With(labels Labels) Metrics {
+	return &labeledMetrics{registry: r, labels: labels}
+}
+
+// WritePrometheus writes all metrics in the Prometheus text exposition format, sorted by name and labels.
+func (r *MetricsRegistry) WritePrometheus(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+
+	for name := range r.families {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	b := bufio.NewWriter(w)
+
+	for _, name := range names {
+		family := r.families[name]
+
+		keys := make([]string, 0, len(family.series))
+
+		for key := range family.series {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		b.WriteString("# TYPE " + name + " " + family.kind.String() + "\n")
+
+		for _, key := range keys {
+			series := family.series[key]
+
+			if family.kind != histogramKind {
+				writeSample(b, name, key, series.value)
+
+				continue
+			}
+
+			for i, bound := range DefaultBuckets {
+				writeSample(b, name+"_bucket", withLabel(key, "le", formatFloat(bound)), float64(series.buckets[i]))
+			}
+
+			writeSample(b, name+"_bucket", withLabel(key, "le", "+Inf"), float64(series.count))
+			writeSample(b, name+"_sum", key, series.value)
+			writeSample(b, name+"_count", key, float64(series.count))
+		}
+	}
+
+	return b.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format, so that the registry can be scraped.
+func (r *MetricsRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	r.WritePrometheus(w)
+}
+
+type labeledMetrics struct {
+	registry *MetricsRegistry
+	labels   Labels
+}
+
+func (m *labeledMetrics) merge(labels Labels) Labels {
+	merged := make(Labels, len(labels)+len(m.labels))
+
+	for name, value := range labels {
+		merged[name] = value
+	}
+
+	for name, value := range m.labels {
+		merged[name] = value
+	}
+
+	return merged
+}
+
+func (m *labeledMetrics) AddCounter(name string, delta float64, labels Labels) {
+	m.registry.AddCounter(name, delta, m.merge(labels))
+}
+
+func (m *labeledMetrics) SetGauge(name string, value float64, labels Labels) {
+	m.registry.SetGauge(name, value, m.merge(labels))
+}
+
+func (m *labeledMetrics) ObserveHistogram(name string, value float64, labels Labels) {
+	m.registry.ObserveHistogram(name, value, m.merge(labels))
+}
+
+func (m *labeledMetrics) MergeHistogram(name string, buckets []uint64, sum float64, count uint64, labels Labels) {
+	m.registry.MergeHistogram(name, buckets, sum, count, m.merge(labels))
+}
+
+// formatLabels formats `labels` as they appear in the text format, sorted by name and without the braces, e.g.
+// `a="1",b="2"`. It reports false if a label name is invalid.
+func formatLabels(labels Labels) (string, bool) {
+	names := make([]string, 0, len(labels))
+
+	for name := range labels {
+		if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") || name == "le" {
+			return "", false
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+
+	for i, name := range names {
+		pairs[i] = name + `="` + labelValueReplacer.Replace(labels[name]) + `"`
+	}
+
+	return strings.Join(pairs, ","), true
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func withLabel(key, name, value string) string {
+	label := name + `="` + value + `"`
+
+	if key == "" {
+		return label
+	}
+
+	return key + "," + label
+}
+
+func writeSample(w *bufio.Writer, name, labels string, value float64) {
+	w.WriteString(name)
+
+	if labels != "" {
+		w.WriteString("{" + labels + "}")
+	}
+
+	w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
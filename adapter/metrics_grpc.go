@@ -0,0 +1,206 @@
+package adapter
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// metricsFlushInterval is how long a plugin aggregates its metrics before it sends them to the host. The remaining
+// metrics are sent when the stub is closed.
+const metricsFlushInterval = time.Second
+
+// droppedMetricsCounter is the counter of the samples that a plugin failed to send to the host.
+const droppedMetricsCounter = "plugin_metrics_dropped_total"
+
+// metricsBuffer aggregates the metric samples of a plugin until they are sent to the host: the increments of a counter
+// are summed, a gauge keeps its last value, and the observations of a histogram are counted in DefaultBuckets.
+type metricsBuffer struct {
+	mu      sync.Mutex
+	samples map[string]*proto.MetricSample
+
+	// dropped is the number of samples dropped since the last flush, and total the number dropped since the start
+	dropped uint64
+	total   uint64
+}
+
+// add merges `sample` into the buffer, and reports whether the buffer was empty.
+func (b *metricsBuffer) add(sample *proto.MetricSample) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	empty := len(b.samples) == 0
+
+	if b.samples == nil {
+		b.samples = make(map[string]*proto.MetricSample)
+	}
+
+	key := metricSampleKey(sample)
+	buffered, ok := b.samples[key]
+
+	switch {
+	case !ok:
+		b.samples[key] = sample
+	case sample.Kind == proto.MetricKind_COUNTER:
+		buffered.Value += sample.Value
+	case sample.Kind == proto.MetricKind_GAUGE:
+		buffered.Value = sample.Value
+	default:
+		for i, n := range sample.Buckets {
+			buffered.Buckets[i] += n
+		}
+
+		buffered.Value += sample.Value
+		buffered.Count += sample.Count
+	}
+
+	return empty
+}
+
+// take empties the buffer, returning its samples and the number of samples dropped since the last take.
+func (b *metricsBuffer) take() (samples []*proto.MetricSample, dropped uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	samples = make([]*proto.MetricSample, 0, len(b.samples))
+
+	for _, sample := range b.samples {
+		samples = append(samples, sample)
+	}
+
+	b.samples = nil
+	dropped, b.dropped = b.dropped, 0
+
+	return samples, dropped
+}
+
+// drop counts the `samples` that could not be sent, so that the host is told about them with the next flush, as
+// well as the `dropped` ones that were to be reported with them.
+func (b *metricsBuffer) drop(samples int, dropped uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.dropped += dropped + uint64(samples)
+	b.total += uint64(samples)
+}
+
+// droppedTotal returns the number of samples dropped since the start.
+func (b *metricsBuffer) droppedTotal() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.total
+}
+
+func metricSampleKey(sample *proto.MetricSample) string {
+	names := make([]string, 0, len(sample.Labels))
+
+	for name := range sample.Labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	key := []string{sample.Kind.String(), sample.Name}
+
+	for _, name := range names {
+		key = append(key, name, sample.Labels[name])
+	}
+
+	return strings.Join(key, "\x00")
+}
+
+func (m *GRPCStubHelperClient) AddCounter(name string, delta float64, labels Labels) {
+	m.record(&proto.MetricSample{Kind: proto.MetricKind_COUNTER, Name: name, Labels: copyLabels(labels), Value: delta})
+}
+
+func (m *GRPCStubHelperClient) SetGauge(name string, value float64, labels Labels) {
+	m.record(&proto.MetricSample{Kind: proto.MetricKind_GAUGE, Name: name, Labels: copyLabels(labels), Value: value})
+}
+
+func (m *GRPCStubHelperClient) ObserveHistogram(name string, value float64, labels Labels) {
+	buckets := make([]uint64, len(DefaultBuckets))
+	observeBuckets(buckets, value)
+
+	m.record(&proto.MetricSample{Kind: proto.MetricKind_HISTOGRAM, Name: name, Labels: copyLabels(labels), Value: value, Buckets: buckets, Count: 1})
+}
+
+// DroppedMetrics returns the number of metric samples that the stub dropped because they could not be sent to the
+// host.
+func (m *GRPCStubHelperClient) DroppedMetrics() uint64 {
+	return m.metrics.droppedTotal()
+}
+
+// record buffers `sample`, and schedules sending the buffer to the host if it was empty. Hosts that predate the
+// RecordMetrics RPC get no metrics.
+func (m *GRPCStubHelperClient) record(sample *proto.MetricSample) {
+	if atomic.LoadInt32(&m.legacyMetrics) == 1 {
+		return
+	}
+
+	if m.metrics.add(sample) {
+		time.AfterFunc(metricsFlushInterval, m.flushMetrics)
+	}
+}
+
+// flushMetrics sends the buffered metrics to the host.
+func (m *GRPCStubHelperClient) flushMetrics() {
+	// flushes are serialized, so that an older value of a gauge can't overwrite a newer one on the host
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+
+	samples, dropped := m.metrics.take()
+
+	if len(samples) == 0 && dropped == 0 {
+		return
+	}
+
+	_, err := m.client.RecordMetrics(context.Background(), &proto.RecordMetricsRequest{Samples: samples, Dropped: dropped})
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		atomic.StoreInt32(&m.legacyMetrics, 1)
+	case err != nil:
+		m.metrics.drop(len(samples), dropped)
+	}
+}
+
+func copyLabels(labels Labels) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]string, len(labels))
+
+	for name, value := range labels {
+		copied[name] = value
+	}
+
+	return copied
+}
+
+func (m *GRPCStubServer) RecordMetrics(ctx context.Context, req *proto.RecordMetricsRequest) (*proto.RecordMetricsResponse, error) {
+	for _, sample := range req.Samples {
+		switch sample.Kind {
+		case proto.MetricKind_COUNTER:
+			m.Impl.AddCounter(sample.Name, sample.Value, sample.Labels)
+		case proto.MetricKind_GAUGE:
+			m.Impl.SetGauge(sample.Name, sample.Value, sample.Labels)
+		case proto.MetricKind_HISTOGRAM:
+			mergeHistogram(m.Impl, sample.Name, sample.Buckets, sample.Value, sample.Count, sample.Labels)
+		}
+	}
+
+	if req.Dropped > 0 {
+		m.Impl.AddCounter(droppedMetricsCounter, float64(req.Dropped), nil)
+	}
+
+	return &proto.RecordMetricsResponse{}, nil
+}
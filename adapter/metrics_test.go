@@ -0,0 +1,190 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/unchainio/interfaces/adapter/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsRegistry(t *testing.T) {
+	registry := NewMetricsRegistry()
+	metrics := registry.With(Labels{"plugin": "orders"})
+
+	metrics.AddCounter("records_polled_total", 2, Labels{"table": "a"})
+	metrics.AddCounter("records_polled_total", 3, Labels{"table": "a", "plugin": "spoofed"})
+	metrics.AddCounter("records_polled_total", -1, Labels{"table": "a"})
+	metrics.SetGauge("records_polled_total", 7, nil)
+	metrics.SetGauge("queue_depth", 4, nil)
+	metrics.SetGauge("queue_depth", 1, nil)
+	metrics.ObserveHistogram("poll_duration_seconds", 0.02, nil)
+	metrics.ObserveHistogram("poll_duration_seconds", 3, nil)
+	metrics.AddCounter("invalid-name", 1, nil)
+	metrics.AddCounter("invalid_label", 1, Labels{"le": "1"})
+
+	if value := registry.Value("records_polled_total", Labels{"plugin": "orders", "table": "a"}); value != 5 {
+		t.Fatalf("expected the counter to sum its increments, got %v", value)
+	}
+
+	var out bytes.Buffer
+
+	if err := registry.WritePrometheus(&out); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+
+	expected := `# TYPE poll_duration_seconds histogram
+poll_duration_seconds_bucket{plugin="orders",le="0.005"} 0
+poll_duration_seconds_bucket{plugin="orders",le="0.01"} 0
+poll_duration_seconds_bucket{plugin="orders",le="0.025"} 1
+poll_duration_seconds_bucket{plugin="orders",le="0.05"} 1
+poll_duration_seconds_bucket{plugin="orders",le="0.1"} 1
+poll_duration_seconds_bucket{plugin="orders",le="0.25"} 1
+poll_duration_seconds_bucket{plugin="orders",le="0.5"} 1
+poll_duration_seconds_bucket{plugin="orders",le="1"} 1
+poll_duration_seconds_bucket{plugin="orders",le="2.5"} 1
+poll_duration_seconds_bucket{plugin="orders",le="5"} 2
+poll_duration_seconds_bucket{plugin="orders",le="10"} 2
+poll_duration_seconds_bucket{plugin="orders",le="+Inf"} 2
+poll_duration_seconds_sum{plugin="orders"} 3.02
+poll_duration_seconds_count{plugin="orders"} 2
+# TYPE queue_depth gauge
+queue_depth{plugin="orders"} 1
+# TYPE records_polled_total counter
+records_polled_total{plugin="orders",table="a"} 5
+`
+
+	if out.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestMetricsRegistryEscapesLabelValues(t *testing.T) {
+	registry := NewMetricsRegistry()
+	registry.AddCounter("errors_total", 1, Labels{"error": "bad \"input\"\n"})
+
+	var out bytes.Buffer
+	registry.WritePrometheus(&out)
+
+	if expected := `errors_total{error="bad \"input\"\n"} 1`; !strings.Contains(out.String(), expected) {
+		t.Fatalf("expected %q, got %q", expected, out.String())
+	}
+}
+
+// metricsEndpoint records metrics at Send.
+type metricsEndpoint struct {
+	queueEndpoint
+}
+
+func (e *metricsEndpoint) Send(stub Stub, message *Message) (*Message, error) {
+	stub.AddCounter("sent_total", 1, Labels{"table": "orders"})
+	stub.AddCounter("sent_total", 1, Labels{"table": "orders"})
+	stub.SetGauge("batch_size", float64(len(message.Body)), nil)
+	stub.ObserveHistogram("send_duration_seconds", 0.5, nil)
+
+	return message, nil
+}
+
+func TestGRPCStubMetrics(t *testing.T) {
+	registry := NewMetricsRegistry()
+	stub := NewStub(testStub{}, WithMetrics(registry.With(Labels{"plugin": "out"})))
+
+	endpoint := dispenseEndpoint(t, &metricsEndpoint{})
+
+	if err := endpoint.Init(stub, nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	if _, err := endpoint.Send(stub, NewMessage([]byte("abc"))); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	if err := endpoint.Close(stub); err != nil {
+		t.Fatalf("failed to close endpoint: %v", err)
+	}
+
+	if value := registry.Value("sent_total", Labels{"plugin": "out", "table": "orders"}); value != 2 {
+		t.Fatalf("expected the counter to be forwarded once the endpoint has been closed, got %v", value)
+	}
+
+	if value := registry.Value("batch_size", Labels{"plugin": "out"}); value != 3 {
+		t.Fatalf("expected the gauge to be forwarded, got %v", value)
+	}
+
+	if value := registry.Value("send_duration_seconds", Labels{"plugin": "out"}); value != 1 {
+		t.Fatalf("expected one observation of the histogram, got %v", value)
+	}
+}
+
+// observationMetrics collects the observations of histograms, and is no HistogramMerger.
+type observationMetrics struct {
+	discardMetrics
+	observations []float64
+}
+
+func (m *observationMetrics) ObserveHistogram(name string, value float64, labels Labels) {
+	m.observations = append(m.observations, value)
+}
+
+func TestMergeHistogram(t *testing.T) {
+	buckets := make([]uint64, len(DefaultBuckets))
+	observeBuckets(buckets, 0.025)
+	observeBuckets(buckets, 20)
+	observeBuckets(buckets, 30)
+
+	registry := NewMetricsRegistry()
+	mergeHistogram(registry, "poll_duration_seconds", buckets, 50.025, 3, nil)
+
+	if value := registry.Value("poll_duration_seconds", nil); value != 3 {
+		t.Fatalf("expected the registry to merge the observations, got %v", value)
+	}
+
+	metrics := &observationMetrics{}
+	mergeHistogram(metrics, "poll_duration_seconds", buckets, 50.025, 3, nil)
+
+	if len(metrics.observations) != 3 || metrics.observations[0] != 0.025 || metrics.observations[1] != 25 || metrics.observations[2] != 25 {
+		t.Fatalf("expected the observations at the bound of their bucket, and above the last bound at their average, got %v", metrics.observations)
+	}
+}
+
+// failingMetricsClient fails to record metrics until it is told to record them, and keeps the last request.
+type failingMetricsClient struct {
+	proto.StubHelperClient
+	record bool
+	last   *proto.RecordMetricsRequest
+}
+
+func (c *failingMetricsClient) RecordMetrics(ctx context.Context, in *proto.RecordMetricsRequest, opts ...grpc.CallOption) (*proto.RecordMetricsResponse, error) {
+	if !c.record {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+
+	c.last = in
+
+	return &proto.RecordMetricsResponse{}, nil
+}
+
+func TestGRPCStubMetricsDropped(t *testing.T) {
+	client := &failingMetricsClient{}
+	stub := &GRPCStubHelperClient{client: client}
+
+	stub.AddCounter("sent_total", 1, nil)
+	stub.SetGauge("batch_size", 3, nil)
+	stub.flushMetrics()
+
+	if dropped := stub.DroppedMetrics(); dropped != 2 {
+		t.Fatalf("expected the samples of the failed flush to be dropped, got %d", dropped)
+	}
+
+	client.record = true
+	stub.AddCounter("sent_total", 1, nil)
+	stub.flushMetrics()
+
+	if client.last == nil || client.last.Dropped != 2 || len(client.last.Samples) != 1 {
+		t.Fatalf("expected the dropped samples to be reported with the next flush, got %+v", client.last)
+	}
+}
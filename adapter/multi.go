@@ -0,0 +1,44 @@
+package adapter
+
+import "context"
+
+// InvokeMulti invokes `action` on `message` and returns the messages that replace it. Actions that don't implement
+// ContextMultiAction or MultiAction are invoked with Invoke, and replace `message` with the invoked message.
+func InvokeMulti(ctx context.Context, action Action, stub Stub, message *Message) ([]*Message, error) {
+	switch a := action.(type) {
+	case ContextMultiAction:
+		return a.InvokeMultiContext(ctx, stub, message)
+	case MultiAction:
+		return invokeMultiContext(ctx, a, stub, message)
+	}
+
+	if err := NewContextAction(action).InvokeContext(ctx, stub, message); err != nil {
+		return nil, err
+	}
+
+	return []*Message{message}, nil
+}
+
+// invokeMultiContext runs InvokeMulti on a copy of the message, like contextAction does for Invoke, so that an
+// abandoned call never modifies the message after it has been abandoned.
+func invokeMultiContext(ctx context.Context, action MultiAction, stub Stub, message *Message) ([]*Message, error) {
+	if ctx.Done() == nil {
+		return action.InvokeMulti(stub, message)
+	}
+
+	invoked := cloneMessage(message)
+
+	var messages []*Message
+
+	err := runContext(ctx, func() (err error) {
+		messages, err = action.InvokeMulti(stub, invoked)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -24,8 +24,10 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type InitActionRequest struct {
-	StubServer           uint32   `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
-	Config               []byte   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	StubServer uint32 `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
+	Config     []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// the optional features that the host supports, e.g. chunking
+	HostCapabilities     []string `protobuf:"bytes,3,rep,name=host_capabilities,json=hostCapabilities,proto3" json:"host_capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -35,7 +37,7 @@ func (m *InitActionRequest) Reset()         { *m = InitActionRequest{} }
 func (m *InitActionRequest) String() string { return proto.CompactTextString(m) }
 func (*InitActionRequest) ProtoMessage()    {}
 func (*InitActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_34031451891ae1e7, []int{0}
+	return fileDescriptor_action_243990fde60c53db, []int{0}
 }
 func (m *InitActionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InitActionRequest.Unmarshal(m, b)
@@ -69,6 +71,13 @@ func (m *InitActionRequest) GetConfig() []byte {
 	return nil
 }
 
+func (m *InitActionRequest) GetHostCapabilities() []string {
+	if m != nil {
+		return m.HostCapabilities
+	}
+	return nil
+}
+
 type InitActionResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -79,7 +88,7 @@ func (m *InitActionResponse) Reset()         { *m = InitActionResponse{} }
 func (m *InitActionResponse) String() string { return proto.CompactTextString(m) }
 func (*InitActionResponse) ProtoMessage()    {}
 func (*InitActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_34031451891ae1e7, []int{1}
+	return fileDescriptor_action_243990fde60c53db, []int{1}
 }
 func (m *InitActionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InitActionResponse.Unmarshal(m, b)
@@ -111,7 +120,7 @@ func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
 func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
 func (*InvokeRequest) ProtoMessage()    {}
 func (*InvokeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_34031451891ae1e7, []int{2}
+	return fileDescriptor_action_243990fde60c53db, []int{2}
 }
 func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
@@ -156,7 +165,7 @@ func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
 func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
 func (*InvokeResponse) ProtoMessage()    {}
 func (*InvokeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_34031451891ae1e7, []int{3}
+	return fileDescriptor_action_243990fde60c53db, []int{3}
 }
 func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
@@ -183,11 +192,198 @@ func (m *InvokeResponse) GetMessage() *AdapterMessage {
 	return nil
 }
 
+type InvokeMultiResponse struct {
+	Messages             []*AdapterMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InvokeMultiResponse) Reset()         { *m = InvokeMultiResponse{} }
+func (m *InvokeMultiResponse) String() string { return proto.CompactTextString(m) }
+func (*InvokeMultiResponse) ProtoMessage()    {}
+func (*InvokeMultiResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_243990fde60c53db, []int{4}
+}
+func (m *InvokeMultiResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeMultiResponse.Unmarshal(m, b)
+}
+func (m *InvokeMultiResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeMultiResponse.Marshal(b, m, deterministic)
+}
+func (dst *InvokeMultiResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeMultiResponse.Merge(dst, src)
+}
+func (m *InvokeMultiResponse) XXX_Size() int {
+	return xxx_messageInfo_InvokeMultiResponse.Size(m)
+}
+func (m *InvokeMultiResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeMultiResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeMultiResponse proto.InternalMessageInfo
+
+func (m *InvokeMultiResponse) GetMessages() []*AdapterMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type InvokeBatchRequest struct {
+	StubServer           uint32            `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
+	Messages             []*AdapterMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InvokeBatchRequest) Reset()         { *m = InvokeBatchRequest{} }
+func (m *InvokeBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*InvokeBatchRequest) ProtoMessage()    {}
+func (*InvokeBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_243990fde60c53db, []int{5}
+}
+func (m *InvokeBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeBatchRequest.Unmarshal(m, b)
+}
+func (m *InvokeBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeBatchRequest.Marshal(b, m, deterministic)
+}
+func (dst *InvokeBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeBatchRequest.Merge(dst, src)
+}
+func (m *InvokeBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_InvokeBatchRequest.Size(m)
+}
+func (m *InvokeBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeBatchRequest proto.InternalMessageInfo
+
+func (m *InvokeBatchRequest) GetStubServer() uint32 {
+	if m != nil {
+		return m.StubServer
+	}
+	return 0
+}
+
+func (m *InvokeBatchRequest) GetMessages() []*AdapterMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type InvokeBatchResponse struct {
+	Results              []*BatchItemResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *InvokeBatchResponse) Reset()         { *m = InvokeBatchResponse{} }
+func (m *InvokeBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*InvokeBatchResponse) ProtoMessage()    {}
+func (*InvokeBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_243990fde60c53db, []int{6}
+}
+func (m *InvokeBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeBatchResponse.Unmarshal(m, b)
+}
+func (m *InvokeBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeBatchResponse.Marshal(b, m, deterministic)
+}
+func (dst *InvokeBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeBatchResponse.Merge(dst, src)
+}
+func (m *InvokeBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_InvokeBatchResponse.Size(m)
+}
+func (m *InvokeBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeBatchResponse proto.InternalMessageInfo
+
+func (m *InvokeBatchResponse) GetResults() []*BatchItemResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type CloseActionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseActionRequest) Reset()         { *m = CloseActionRequest{} }
+func (m *CloseActionRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseActionRequest) ProtoMessage()    {}
+func (*CloseActionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_243990fde60c53db, []int{7}
+}
+func (m *CloseActionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseActionRequest.Unmarshal(m, b)
+}
+func (m *CloseActionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseActionRequest.Marshal(b, m, deterministic)
+}
+func (dst *CloseActionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseActionRequest.Merge(dst, src)
+}
+func (m *CloseActionRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseActionRequest.Size(m)
+}
+func (m *CloseActionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseActionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseActionRequest proto.InternalMessageInfo
+
+type CloseActionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseActionResponse) Reset()         { *m = CloseActionResponse{} }
+func (m *CloseActionResponse) String() string { return proto.CompactTextString(m) }
+func (*CloseActionResponse) ProtoMessage()    {}
+func (*CloseActionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_243990fde60c53db, []int{8}
+}
+func (m *CloseActionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseActionResponse.Unmarshal(m, b)
+}
+func (m *CloseActionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseActionResponse.Marshal(b, m, deterministic)
+}
+func (dst *CloseActionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseActionResponse.Merge(dst, src)
+}
+func (m *CloseActionResponse) XXX_Size() int {
+	return xxx_messageInfo_CloseActionResponse.Size(m)
+}
+func (m *CloseActionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseActionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseActionResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*InitActionRequest)(nil), "proto.InitActionRequest")
 	proto.RegisterType((*InitActionResponse)(nil), "proto.InitActionResponse")
 	proto.RegisterType((*InvokeRequest)(nil), "proto.InvokeRequest")
 	proto.RegisterType((*InvokeResponse)(nil), "proto.InvokeResponse")
+	proto.RegisterType((*InvokeMultiResponse)(nil), "proto.InvokeMultiResponse")
+	proto.RegisterType((*InvokeBatchRequest)(nil), "proto.InvokeBatchRequest")
+	proto.RegisterType((*InvokeBatchResponse)(nil), "proto.InvokeBatchResponse")
+	proto.RegisterType((*CloseActionRequest)(nil), "proto.CloseActionRequest")
+	proto.RegisterType((*CloseActionResponse)(nil), "proto.CloseActionResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -202,8 +398,15 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ActionClient interface {
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
 	Init(ctx context.Context, in *InitActionRequest, opts ...grpc.CallOption) (*InitActionResponse, error)
 	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+	InvokeMulti(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeMultiResponse, error)
+	InvokeBatch(ctx context.Context, in *InvokeBatchRequest, opts ...grpc.CallOption) (*InvokeBatchResponse, error)
+	UploadBody(ctx context.Context, opts ...grpc.CallOption) (Action_UploadBodyClient, error)
+	DownloadBody(ctx context.Context, in *DownloadBodyRequest, opts ...grpc.CallOption) (Action_DownloadBodyClient, error)
+	// Close delivers what the plugin buffered for the stub passed at Init, before the host stops serving it
+	Close(ctx context.Context, in *CloseActionRequest, opts ...grpc.CallOption) (*CloseActionResponse, error)
 }
 
 type actionClient struct {
@@ -214,6 +417,15 @@ func NewActionClient(cc *grpc.ClientConn) ActionClient {
 	return &actionClient{cc}
 }
 
+func (c *actionClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, "/proto.Action/Describe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *actionClient) Init(ctx context.Context, in *InitActionRequest, opts ...grpc.CallOption) (*InitActionResponse, error) {
 	out := new(InitActionResponse)
 	err := c.cc.Invoke(ctx, "/proto.Action/Init", in, out, opts...)
@@ -232,16 +444,134 @@ func (c *actionClient) Invoke(ctx context.Context, in *InvokeRequest, opts ...gr
 	return out, nil
 }
 
+func (c *actionClient) InvokeMulti(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeMultiResponse, error) {
+	out := new(InvokeMultiResponse)
+	err := c.cc.Invoke(ctx, "/proto.Action/InvokeMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actionClient) InvokeBatch(ctx context.Context, in *InvokeBatchRequest, opts ...grpc.CallOption) (*InvokeBatchResponse, error) {
+	out := new(InvokeBatchResponse)
+	err := c.cc.Invoke(ctx, "/proto.Action/InvokeBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actionClient) UploadBody(ctx context.Context, opts ...grpc.CallOption) (Action_UploadBodyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Action_serviceDesc.Streams[0], "/proto.Action/UploadBody", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &actionUploadBodyClient{stream}
+	return x, nil
+}
+
+type Action_UploadBodyClient interface {
+	Send(*BodyChunk) error
+	CloseAndRecv() (*UploadBodyResponse, error)
+	grpc.ClientStream
+}
+
+type actionUploadBodyClient struct {
+	grpc.ClientStream
+}
+
+func (x *actionUploadBodyClient) Send(m *BodyChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *actionUploadBodyClient) CloseAndRecv() (*UploadBodyResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBodyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *actionClient) DownloadBody(ctx context.Context, in *DownloadBodyRequest, opts ...grpc.CallOption) (Action_DownloadBodyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Action_serviceDesc.Streams[1], "/proto.Action/DownloadBody", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &actionDownloadBodyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Action_DownloadBodyClient interface {
+	Recv() (*BodyChunk, error)
+	grpc.ClientStream
+}
+
+type actionDownloadBodyClient struct {
+	grpc.ClientStream
+}
+
+func (x *actionDownloadBodyClient) Recv() (*BodyChunk, error) {
+	m := new(BodyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *actionClient) Close(ctx context.Context, in *CloseActionRequest, opts ...grpc.CallOption) (*CloseActionResponse, error) {
+	out := new(CloseActionResponse)
+	err := c.cc.Invoke(ctx, "/proto.Action/Close", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ActionServer is the server API for Action service.
 type ActionServer interface {
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
 	Init(context.Context, *InitActionRequest) (*InitActionResponse, error)
 	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+	InvokeMulti(context.Context, *InvokeRequest) (*InvokeMultiResponse, error)
+	InvokeBatch(context.Context, *InvokeBatchRequest) (*InvokeBatchResponse, error)
+	UploadBody(Action_UploadBodyServer) error
+	DownloadBody(*DownloadBodyRequest, Action_DownloadBodyServer) error
+	// Close delivers what the plugin buffered for the stub passed at Init, before the host stops serving it
+	Close(context.Context, *CloseActionRequest) (*CloseActionResponse, error)
 }
 
 func RegisterActionServer(s *grpc.Server, srv ActionServer) {
 	s.RegisterService(&_Action_serviceDesc, srv)
 }
 
+func _Action_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Action/Describe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Action_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InitActionRequest)
 	if err := dec(in); err != nil {
@@ -278,10 +608,115 @@ func _Action_Invoke_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Action_InvokeMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionServer).InvokeMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Action/InvokeMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionServer).InvokeMulti(ctx, req.(*InvokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Action_InvokeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionServer).InvokeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Action/InvokeBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionServer).InvokeBatch(ctx, req.(*InvokeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Action_UploadBody_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ActionServer).UploadBody(&actionUploadBodyServer{stream})
+}
+
+type Action_UploadBodyServer interface {
+	SendAndClose(*UploadBodyResponse) error
+	Recv() (*BodyChunk, error)
+	grpc.ServerStream
+}
+
+type actionUploadBodyServer struct {
+	grpc.ServerStream
+}
+
+func (x *actionUploadBodyServer) SendAndClose(m *UploadBodyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *actionUploadBodyServer) Recv() (*BodyChunk, error) {
+	m := new(BodyChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Action_DownloadBody_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBodyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActionServer).DownloadBody(m, &actionDownloadBodyServer{stream})
+}
+
+type Action_DownloadBodyServer interface {
+	Send(*BodyChunk) error
+	grpc.ServerStream
+}
+
+type actionDownloadBodyServer struct {
+	grpc.ServerStream
+}
+
+func (x *actionDownloadBodyServer) Send(m *BodyChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Action_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionServer).Close(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Action/Close",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionServer).Close(ctx, req.(*CloseActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Action_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Action",
 	HandlerType: (*ActionServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _Action_Describe_Handler,
+		},
 		{
 			MethodName: "Init",
 			Handler:    _Action_Init_Handler,
@@ -290,28 +725,65 @@ var _Action_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Invoke",
 			Handler:    _Action_Invoke_Handler,
 		},
+		{
+			MethodName: "InvokeMulti",
+			Handler:    _Action_InvokeMulti_Handler,
+		},
+		{
+			MethodName: "InvokeBatch",
+			Handler:    _Action_InvokeBatch_Handler,
+		},
+		{
+			MethodName: "Close",
+			Handler:    _Action_Close_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadBody",
+			Handler:       _Action_UploadBody_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadBody",
+			Handler:       _Action_DownloadBody_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "action.proto",
 }
 
-func init() { proto.RegisterFile("action.proto", fileDescriptor_action_34031451891ae1e7) }
-
-var fileDescriptor_action_34031451891ae1e7 = []byte{
-	// 230 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0x4c, 0x2e, 0xc9,
-	0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x52, 0xbc, 0xb9, 0xa9,
-	0xc5, 0xc5, 0x89, 0xe9, 0xa9, 0x10, 0x51, 0x25, 0x1f, 0x2e, 0x41, 0xcf, 0xbc, 0xcc, 0x12, 0x47,
-	0xb0, 0xca, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x21, 0x79, 0x2e, 0xee, 0xe2, 0x92, 0xd2,
-	0xa4, 0xf8, 0xe2, 0xd4, 0xa2, 0xb2, 0xd4, 0x22, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xde, 0x20, 0x2e,
-	0x90, 0x50, 0x30, 0x58, 0x44, 0x48, 0x8c, 0x8b, 0x2d, 0x39, 0x3f, 0x2f, 0x2d, 0x33, 0x5d, 0x82,
-	0x49, 0x81, 0x51, 0x83, 0x27, 0x08, 0xca, 0x53, 0x12, 0xe1, 0x12, 0x42, 0x36, 0xad, 0xb8, 0x20,
-	0x3f, 0xaf, 0x38, 0x55, 0x29, 0x91, 0x8b, 0xd7, 0x33, 0xaf, 0x2c, 0x3f, 0x3b, 0x95, 0x68, 0xf3,
-	0xf5, 0xb9, 0xd8, 0xa1, 0xce, 0x04, 0x5b, 0xc0, 0x6d, 0x24, 0x0a, 0x71, 0xae, 0x9e, 0x63, 0x4a,
-	0x62, 0x41, 0x49, 0x6a, 0x91, 0x2f, 0x44, 0x32, 0x08, 0xa6, 0x4a, 0xc9, 0x91, 0x8b, 0x0f, 0x66,
-	0x05, 0xc4, 0x52, 0x64, 0x23, 0x18, 0x89, 0x31, 0xc2, 0xa8, 0x86, 0x8b, 0x0d, 0xe2, 0x6e, 0x21,
-	0x6b, 0x2e, 0x16, 0x90, 0x2f, 0x84, 0x24, 0xa0, 0x3a, 0x30, 0x02, 0x48, 0x4a, 0x12, 0x8b, 0x0c,
-	0xd4, 0x5e, 0x53, 0x2e, 0x36, 0x88, 0x4b, 0x84, 0x44, 0xe0, 0x8a, 0x90, 0xfc, 0x2e, 0x25, 0x8a,
-	0x26, 0x0a, 0xd1, 0x96, 0xc4, 0x06, 0x16, 0x35, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x7b, 0x70,
-	0x0d, 0xda, 0xb4, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("action.proto", fileDescriptor_action_243990fde60c53db) }
+
+var fileDescriptor_action_243990fde60c53db = []byte{
+	// 462 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0x6b, 0xdb, 0x40,
+	0x10, 0x45, 0x71, 0xe3, 0xa4, 0x63, 0x3b, 0x24, 0xeb, 0x38, 0x55, 0xf6, 0x52, 0xa3, 0x93, 0xa1,
+	0x90, 0xa6, 0x29, 0x3d, 0x05, 0x5a, 0x1c, 0x1b, 0x5a, 0x1f, 0x72, 0x51, 0xe9, 0x39, 0xac, 0xa4,
+	0x6d, 0xb4, 0x44, 0xd1, 0xaa, 0xda, 0x55, 0x8a, 0x7f, 0x7c, 0xa1, 0x68, 0x3f, 0xd4, 0x55, 0x65,
+	0x83, 0x4e, 0x62, 0xdf, 0xbc, 0x79, 0xef, 0x8d, 0x66, 0x60, 0x4c, 0x62, 0xc9, 0x78, 0x7e, 0x55,
+	0x94, 0x5c, 0x72, 0x74, 0xa8, 0x3e, 0x78, 0xf2, 0x4c, 0x85, 0x20, 0x8f, 0x54, 0xa3, 0x78, 0x14,
+	0x11, 0x19, 0xa7, 0xe6, 0x71, 0x92, 0x50, 0x11, 0x97, 0x2c, 0x32, 0xc5, 0x60, 0x0b, 0x67, 0x9b,
+	0x9c, 0xc9, 0xa5, 0x92, 0x09, 0xe9, 0xaf, 0x8a, 0x0a, 0x89, 0xde, 0xc2, 0x48, 0xc8, 0x2a, 0x7a,
+	0x10, 0xb4, 0x7c, 0xa1, 0xa5, 0xef, 0xcd, 0xbd, 0xc5, 0x24, 0x84, 0x1a, 0xfa, 0xae, 0x10, 0x74,
+	0x01, 0xc3, 0x98, 0xe7, 0x3f, 0xd9, 0xa3, 0x7f, 0x30, 0xf7, 0x16, 0xe3, 0xd0, 0xbc, 0xd0, 0x3b,
+	0x38, 0x4b, 0xb9, 0x90, 0x0f, 0x31, 0x29, 0x48, 0xc4, 0x32, 0x26, 0x19, 0x15, 0xfe, 0x60, 0x3e,
+	0x58, 0xbc, 0x0e, 0x4f, 0xeb, 0xc2, 0xca, 0xc1, 0x83, 0x73, 0x40, 0xae, 0xb5, 0x28, 0x78, 0x2e,
+	0x68, 0x40, 0x60, 0xb2, 0xc9, 0x5f, 0xf8, 0x13, 0xed, 0x1d, 0xe6, 0x3d, 0x1c, 0x99, 0x81, 0x55,
+	0x9a, 0xd1, 0xcd, 0x4c, 0xcf, 0x76, 0xb5, 0x4c, 0x48, 0x21, 0x69, 0x79, 0xaf, 0x8b, 0xa1, 0x65,
+	0x05, 0x4b, 0x38, 0xb1, 0x16, 0xda, 0xd4, 0x95, 0xf0, 0x7a, 0x49, 0x7c, 0x83, 0xa9, 0x96, 0xb8,
+	0xaf, 0x32, 0xc9, 0x1a, 0x9d, 0x0f, 0x70, 0x6c, 0x18, 0xc2, 0xf7, 0xe6, 0x83, 0xfd, 0x42, 0x0d,
+	0x2d, 0x48, 0x01, 0x69, 0xa5, 0xbb, 0x7a, 0x4b, 0xbd, 0x87, 0x76, 0x9d, 0x0e, 0xfa, 0x39, 0x7d,
+	0x85, 0x69, 0xcb, 0xc9, 0x64, 0xbe, 0x86, 0xa3, 0x92, 0x8a, 0x2a, 0x93, 0x36, 0xf2, 0x85, 0x11,
+	0x52, 0xb4, 0x8d, 0xa4, 0xcf, 0xa1, 0x2a, 0x87, 0x96, 0x56, 0x2f, 0x6e, 0x95, 0x71, 0x41, 0x5b,
+	0x47, 0x13, 0xcc, 0x60, 0xda, 0x42, 0xb5, 0xfc, 0xcd, 0x9f, 0x01, 0x0c, 0x35, 0x84, 0x6e, 0xe1,
+	0x78, 0x6d, 0xae, 0x0f, 0x59, 0x13, 0x0b, 0x18, 0x15, 0xfc, 0xa6, 0x83, 0x9b, 0x98, 0xb7, 0xf0,
+	0xaa, 0xbe, 0x16, 0xe4, 0x1b, 0x42, 0xe7, 0x6a, 0xf1, 0xe5, 0x8e, 0x8a, 0x69, 0xfe, 0x04, 0x43,
+	0x3d, 0x3a, 0x3a, 0x6f, 0x48, 0xce, 0x8d, 0xe1, 0xd9, 0x7f, 0xa8, 0x69, 0xfb, 0x02, 0x23, 0x67,
+	0xcb, 0x7b, 0x7a, 0x71, 0x0b, 0x6d, 0xdf, 0xc3, 0xda, 0x0a, 0xa8, 0x7f, 0x89, 0x2e, 0x5b, 0x54,
+	0x77, 0xe1, 0x18, 0xef, 0x2a, 0x35, 0xa3, 0xc3, 0x8f, 0x22, 0xe3, 0x24, 0xb9, 0xe3, 0xc9, 0x16,
+	0x9d, 0xda, 0xf5, 0xf0, 0x64, 0xbb, 0x4a, 0xab, 0xfc, 0xa9, 0x19, 0xfc, 0x1f, 0xc9, 0xb6, 0x2e,
+	0x3c, 0xf4, 0x19, 0xc6, 0x6b, 0xfe, 0x3b, 0x6f, 0xda, 0xad, 0x91, 0x0b, 0xda, 0x10, 0x1d, 0xe9,
+	0xeb, 0xba, 0xff, 0x50, 0xad, 0xb5, 0x09, 0xdf, 0x5d, 0x3d, 0xc6, 0xbb, 0x4a, 0x3a, 0x41, 0x34,
+	0x54, 0xa5, 0x8f, 0x7f, 0x07, 0x00, 0x62, 0x34, 0x2a, 0x0d, 0xaa, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: batch.proto
+
+package proto
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// BatchItemResult is the outcome of a single message of a batch, in the order of the request.
+type BatchItemResult struct {
+	// the response of SendBatch, or the invoked message of InvokeBatch
+	Message *AdapterMessage `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Failed  bool            `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Error   string          `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// set if the error is an adapter.Error, in which case `error` is its message
+	ErrorDetail          *ErrorDetail `protobuf:"bytes,4,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BatchItemResult) Reset()         { *m = BatchItemResult{} }
+func (m *BatchItemResult) String() string { return proto.CompactTextString(m) }
+func (*BatchItemResult) ProtoMessage()    {}
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_batch_ca509fcbbd3e120c, []int{0}
+}
+func (m *BatchItemResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchItemResult.Unmarshal(m, b)
+}
+func (m *BatchItemResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchItemResult.Marshal(b, m, deterministic)
+}
+func (dst *BatchItemResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchItemResult.Merge(dst, src)
+}
+func (m *BatchItemResult) XXX_Size() int {
+	return xxx_messageInfo_BatchItemResult.Size(m)
+}
+func (m *BatchItemResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchItemResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchItemResult proto.InternalMessageInfo
+
+func (m *BatchItemResult) GetMessage() *AdapterMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *BatchItemResult) GetFailed() bool {
+	if m != nil {
+		return m.Failed
+	}
+	return false
+}
+
+func (m *BatchItemResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *BatchItemResult) GetErrorDetail() *ErrorDetail {
+	if m != nil {
+		return m.ErrorDetail
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BatchItemResult)(nil), "proto.BatchItemResult")
+}
+
+func init() { proto.RegisterFile("batch.proto", fileDescriptor_batch_ca509fcbbd3e120c) }
+
+var fileDescriptor_batch_ca509fcbbd3e120c = []byte{
+	// 174 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4e, 0x4a, 0x2c, 0x49,
+	0xce, 0xd0, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x52, 0xbc, 0xb9, 0xa9, 0xc5,
+	0xc5, 0x89, 0xe9, 0xa9, 0x10, 0x51, 0x29, 0xee, 0xd4, 0xa2, 0xa2, 0xfc, 0x22, 0x08, 0x47, 0x69,
+	0x39, 0x23, 0x17, 0xbf, 0x13, 0x48, 0x8b, 0x67, 0x49, 0x6a, 0x6e, 0x50, 0x6a, 0x71, 0x69, 0x4e,
+	0x89, 0x90, 0x3e, 0x17, 0x3b, 0x54, 0x87, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0xb7, 0x91, 0x28, 0x44,
+	0xb1, 0x9e, 0x63, 0x4a, 0x62, 0x41, 0x49, 0x6a, 0x91, 0x2f, 0x44, 0x32, 0x08, 0xa6, 0x4a, 0x48,
+	0x8c, 0x8b, 0x2d, 0x2d, 0x31, 0x33, 0x27, 0x35, 0x45, 0x82, 0x49, 0x81, 0x51, 0x83, 0x23, 0x08,
+	0xca, 0x13, 0x12, 0xe1, 0x62, 0x05, 0xdb, 0x25, 0xc1, 0xac, 0xc0, 0xa8, 0xc1, 0x19, 0x04, 0xe1,
+	0x08, 0x99, 0x72, 0xf1, 0x80, 0x19, 0xf1, 0x29, 0xa9, 0x25, 0x89, 0x99, 0x39, 0x12, 0x2c, 0x60,
+	0x3b, 0x84, 0xa0, 0x76, 0xb8, 0x82, 0xa4, 0x5c, 0xc0, 0x32, 0x41, 0xdc, 0xa9, 0x08, 0x4e, 0x12,
+	0x1b, 0x58, 0xde, 0x18, 0x30, 0x00, 0x2e, 0x26, 0x12, 0x0f, 0xe2, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: describe.proto
+
+package proto
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// DescribeRequest tells the plugin which optional features the host supports.
+type DescribeRequest struct {
+	HostCapabilities     []string `protobuf:"bytes,1,rep,name=host_capabilities,json=hostCapabilities,proto3" json:"host_capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DescribeRequest) Reset()         { *m = DescribeRequest{} }
+func (m *DescribeRequest) String() string { return proto.CompactTextString(m) }
+func (*DescribeRequest) ProtoMessage()    {}
+func (*DescribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_describe_bd1cf800af370773, []int{0}
+}
+func (m *DescribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeRequest.Unmarshal(m, b)
+}
+func (m *DescribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeRequest.Marshal(b, m, deterministic)
+}
+func (dst *DescribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeRequest.Merge(dst, src)
+}
+func (m *DescribeRequest) XXX_Size() int {
+	return xxx_messageInfo_DescribeRequest.Size(m)
+}
+func (m *DescribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeRequest proto.InternalMessageInfo
+
+func (m *DescribeRequest) GetHostCapabilities() []string {
+	if m != nil {
+		return m.HostCapabilities
+	}
+	return nil
+}
+
+// DescribeResponse tells the host which version of the adapter protocol the plugin speaks, and which optional
+// features it supports.
+type DescribeResponse struct {
+	ProtocolVersion      uint32    `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities         []string  `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Manifest             *Manifest `protobuf:"bytes,3,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DescribeResponse) Reset()         { *m = DescribeResponse{} }
+func (m *DescribeResponse) String() string { return proto.CompactTextString(m) }
+func (*DescribeResponse) ProtoMessage()    {}
+func (*DescribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_describe_bd1cf800af370773, []int{1}
+}
+func (m *DescribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeResponse.Unmarshal(m, b)
+}
+func (m *DescribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeResponse.Marshal(b, m, deterministic)
+}
+func (dst *DescribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeResponse.Merge(dst, src)
+}
+func (m *DescribeResponse) XXX_Size() int {
+	return xxx_messageInfo_DescribeResponse.Size(m)
+}
+func (m *DescribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeResponse proto.InternalMessageInfo
+
+func (m *DescribeResponse) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *DescribeResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *DescribeResponse) GetManifest() *Manifest {
+	if m != nil {
+		return m.Manifest
+	}
+	return nil
+}
+
+// Manifest is what the plugin declares about itself.
+type Manifest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// endpoint or action
+	Kind        string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// the JSON schema of the config of the plugin
+	ConfigSchema         []byte   `protobuf:"bytes,5,opt,name=config_schema,json=configSchema,proto3" json:"config_schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Manifest) Reset()         { *m = Manifest{} }
+func (m *Manifest) String() string { return proto.CompactTextString(m) }
+func (*Manifest) ProtoMessage()    {}
+func (*Manifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_describe_bd1cf800af370773, []int{2}
+}
+func (m *Manifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Manifest.Unmarshal(m, b)
+}
+func (m *Manifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Manifest.Marshal(b, m, deterministic)
+}
+func (dst *Manifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Manifest.Merge(dst, src)
+}
+func (m *Manifest) XXX_Size() int {
+	return xxx_messageInfo_Manifest.Size(m)
+}
+func (m *Manifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Manifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Manifest proto.InternalMessageInfo
+
+func (m *Manifest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Manifest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Manifest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Manifest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Manifest) GetConfigSchema() []byte {
+	if m != nil {
+		return m.ConfigSchema
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DescribeRequest)(nil), "proto.DescribeRequest")
+	proto.RegisterType((*DescribeResponse)(nil), "proto.DescribeResponse")
+	proto.RegisterType((*Manifest)(nil), "proto.Manifest")
+}
+
+func init() { proto.RegisterFile("describe.proto", fileDescriptor_describe_bd1cf800af370773) }
+
+var fileDescriptor_describe_bd1cf800af370773 = []byte{
+	// 253 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x3f, 0x4f, 0xc3, 0x30,
+	0x10, 0xc5, 0xe5, 0xfe, 0x81, 0xe6, 0x9a, 0x92, 0x70, 0x53, 0xc6, 0x28, 0x2c, 0x41, 0x95, 0x3a,
+	0xc0, 0xce, 0x02, 0x2b, 0x8b, 0x91, 0x58, 0x23, 0xc7, 0xbd, 0x52, 0x8b, 0xc6, 0x0e, 0xb1, 0xe1,
+	0x63, 0xb0, 0xf0, 0x85, 0x51, 0x2e, 0x0d, 0xb4, 0x93, 0xcf, 0xbf, 0x77, 0xbe, 0xf7, 0x7c, 0x70,
+	0xb5, 0x25, 0xaf, 0x3b, 0x53, 0xd3, 0xa6, 0xed, 0x5c, 0x70, 0x38, 0xe7, 0xa3, 0x78, 0x80, 0xe4,
+	0xe9, 0x28, 0x48, 0xfa, 0xf8, 0x24, 0x1f, 0x70, 0x0d, 0xd7, 0x7b, 0xe7, 0x43, 0xa5, 0x55, 0xab,
+	0x6a, 0x73, 0x30, 0xc1, 0x90, 0xcf, 0x44, 0x3e, 0x2d, 0x23, 0x99, 0xf6, 0xc2, 0xe3, 0x09, 0x2f,
+	0xbe, 0x05, 0xa4, 0xff, 0x03, 0x7c, 0xeb, 0xac, 0x27, 0xbc, 0x85, 0x94, 0xa7, 0x6b, 0x77, 0xa8,
+	0xbe, 0xa8, 0xf3, 0xc6, 0xd9, 0x4c, 0xe4, 0xa2, 0x5c, 0xc9, 0x64, 0xe4, 0xaf, 0x03, 0xc6, 0x02,
+	0xe2, 0x33, 0x9f, 0x09, 0xfb, 0x9c, 0x31, 0x5c, 0xc3, 0xa2, 0x51, 0xd6, 0xec, 0xc8, 0x87, 0x6c,
+	0x9a, 0x8b, 0x72, 0x79, 0x97, 0x0c, 0x9f, 0xd8, 0x3c, 0x1f, 0xb1, 0xfc, 0x6b, 0x28, 0x7e, 0x04,
+	0x2c, 0x46, 0x8c, 0x08, 0x33, 0xab, 0x1a, 0x62, 0xf3, 0x48, 0x72, 0x8d, 0x19, 0x5c, 0x8e, 0x99,
+	0x26, 0x8c, 0xc7, 0x6b, 0xdf, 0xfd, 0x6e, 0xec, 0x96, 0x3d, 0x22, 0xc9, 0x35, 0xe6, 0xb0, 0x1c,
+	0x16, 0xd7, 0x86, 0xfe, 0xc5, 0x8c, 0xa5, 0x53, 0x84, 0x37, 0xb0, 0xd2, 0xce, 0xee, 0xcc, 0x5b,
+	0xe5, 0xf5, 0x9e, 0x1a, 0x95, 0xcd, 0x73, 0x51, 0xc6, 0x32, 0x1e, 0xe0, 0x0b, 0xb3, 0xfa, 0x82,
+	0xf3, 0xde, 0xff, 0x0e, 0x00, 0x18, 0x66, 0x48, 0x60, 0x86, 0x01, 0x00, 0x00,
+}
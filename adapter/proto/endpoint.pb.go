@@ -24,8 +24,10 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type InitEndpointRequest struct {
-	StubServer           uint32   `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
-	Config               []byte   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	StubServer uint32 `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
+	Config     []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// the optional features that the host supports, e.g. chunking
+	HostCapabilities     []string `protobuf:"bytes,3,rep,name=host_capabilities,json=hostCapabilities,proto3" json:"host_capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -35,7 +37,7 @@ func (m *InitEndpointRequest) Reset()         { *m = InitEndpointRequest{} }
 func (m *InitEndpointRequest) String() string { return proto.CompactTextString(m) }
 func (*InitEndpointRequest) ProtoMessage()    {}
 func (*InitEndpointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{0}
+	return fileDescriptor_endpoint_b183178546864154, []int{0}
 }
 func (m *InitEndpointRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InitEndpointRequest.Unmarshal(m, b)
@@ -69,6 +71,13 @@ func (m *InitEndpointRequest) GetConfig() []byte {
 	return nil
 }
 
+func (m *InitEndpointRequest) GetHostCapabilities() []string {
+	if m != nil {
+		return m.HostCapabilities
+	}
+	return nil
+}
+
 type InitEndpointResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -79,7 +88,7 @@ func (m *InitEndpointResponse) Reset()         { *m = InitEndpointResponse{} }
 func (m *InitEndpointResponse) String() string { return proto.CompactTextString(m) }
 func (*InitEndpointResponse) ProtoMessage()    {}
 func (*InitEndpointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{1}
+	return fileDescriptor_endpoint_b183178546864154, []int{1}
 }
 func (m *InitEndpointResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InitEndpointResponse.Unmarshal(m, b)
@@ -111,7 +120,7 @@ func (m *SendRequest) Reset()         { *m = SendRequest{} }
 func (m *SendRequest) String() string { return proto.CompactTextString(m) }
 func (*SendRequest) ProtoMessage()    {}
 func (*SendRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{2}
+	return fileDescriptor_endpoint_b183178546864154, []int{2}
 }
 func (m *SendRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SendRequest.Unmarshal(m, b)
@@ -156,7 +165,7 @@ func (m *SendResponse) Reset()         { *m = SendResponse{} }
 func (m *SendResponse) String() string { return proto.CompactTextString(m) }
 func (*SendResponse) ProtoMessage()    {}
 func (*SendResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{3}
+	return fileDescriptor_endpoint_b183178546864154, []int{3}
 }
 func (m *SendResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SendResponse.Unmarshal(m, b)
@@ -183,6 +192,90 @@ func (m *SendResponse) GetResponse() *AdapterMessage {
 	return nil
 }
 
+type SendBatchRequest struct {
+	StubServer           uint32            `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
+	Messages             []*AdapterMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SendBatchRequest) Reset()         { *m = SendBatchRequest{} }
+func (m *SendBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*SendBatchRequest) ProtoMessage()    {}
+func (*SendBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_endpoint_b183178546864154, []int{4}
+}
+func (m *SendBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendBatchRequest.Unmarshal(m, b)
+}
+func (m *SendBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendBatchRequest.Marshal(b, m, deterministic)
+}
+func (dst *SendBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendBatchRequest.Merge(dst, src)
+}
+func (m *SendBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_SendBatchRequest.Size(m)
+}
+func (m *SendBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendBatchRequest proto.InternalMessageInfo
+
+func (m *SendBatchRequest) GetStubServer() uint32 {
+	if m != nil {
+		return m.StubServer
+	}
+	return 0
+}
+
+func (m *SendBatchRequest) GetMessages() []*AdapterMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type SendBatchResponse struct {
+	Results              []*BatchItemResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SendBatchResponse) Reset()         { *m = SendBatchResponse{} }
+func (m *SendBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*SendBatchResponse) ProtoMessage()    {}
+func (*SendBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_endpoint_b183178546864154, []int{5}
+}
+func (m *SendBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendBatchResponse.Unmarshal(m, b)
+}
+func (m *SendBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendBatchResponse.Marshal(b, m, deterministic)
+}
+func (dst *SendBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendBatchResponse.Merge(dst, src)
+}
+func (m *SendBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_SendBatchResponse.Size(m)
+}
+func (m *SendBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendBatchResponse proto.InternalMessageInfo
+
+func (m *SendBatchResponse) GetResults() []*BatchItemResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 type ReceiveRequest struct {
 	StubServer           uint32   `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -194,7 +287,7 @@ func (m *ReceiveRequest) Reset()         { *m = ReceiveRequest{} }
 func (m *ReceiveRequest) String() string { return proto.CompactTextString(m) }
 func (*ReceiveRequest) ProtoMessage()    {}
 func (*ReceiveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{4}
+	return fileDescriptor_endpoint_b183178546864154, []int{6}
 }
 func (m *ReceiveRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiveRequest.Unmarshal(m, b)
@@ -232,7 +325,7 @@ func (m *ReceiveResponse) Reset()         { *m = ReceiveResponse{} }
 func (m *ReceiveResponse) String() string { return proto.CompactTextString(m) }
 func (*ReceiveResponse) ProtoMessage()    {}
 func (*ReceiveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{5}
+	return fileDescriptor_endpoint_b183178546864154, []int{7}
 }
 func (m *ReceiveResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiveResponse.Unmarshal(m, b)
@@ -272,7 +365,7 @@ func (m *AckRequest) Reset()         { *m = AckRequest{} }
 func (m *AckRequest) String() string { return proto.CompactTextString(m) }
 func (*AckRequest) ProtoMessage()    {}
 func (*AckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{6}
+	return fileDescriptor_endpoint_b183178546864154, []int{8}
 }
 func (m *AckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AckRequest.Unmarshal(m, b)
@@ -323,7 +416,7 @@ func (m *AckResponse) Reset()         { *m = AckResponse{} }
 func (m *AckResponse) String() string { return proto.CompactTextString(m) }
 func (*AckResponse) ProtoMessage()    {}
 func (*AckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{7}
+	return fileDescriptor_endpoint_b183178546864154, []int{9}
 }
 func (m *AckResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AckResponse.Unmarshal(m, b)
@@ -344,19 +437,21 @@ func (m *AckResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_AckResponse proto.InternalMessageInfo
 
 type NackRequest struct {
-	StubServer           uint32   `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
-	Tag                  uint64   `protobuf:"varint,2,opt,name=tag,proto3" json:"tag,omitempty"`
-	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	StubServer uint32 `protobuf:"varint,1,opt,name=stub_server,json=stubServer,proto3" json:"stub_server,omitempty"`
+	Tag        uint64 `protobuf:"varint,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Error      string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// set if the error is an adapter.Error, in which case `error` is its message
+	ErrorDetail          *ErrorDetail `protobuf:"bytes,4,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *NackRequest) Reset()         { *m = NackRequest{} }
 func (m *NackRequest) String() string { return proto.CompactTextString(m) }
 func (*NackRequest) ProtoMessage()    {}
 func (*NackRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{8}
+	return fileDescriptor_endpoint_b183178546864154, []int{10}
 }
 func (m *NackRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NackRequest.Unmarshal(m, b)
@@ -397,6 +492,13 @@ func (m *NackRequest) GetError() string {
 	return ""
 }
 
+func (m *NackRequest) GetErrorDetail() *ErrorDetail {
+	if m != nil {
+		return m.ErrorDetail
+	}
+	return nil
+}
+
 type NackResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -407,7 +509,7 @@ func (m *NackResponse) Reset()         { *m = NackResponse{} }
 func (m *NackResponse) String() string { return proto.CompactTextString(m) }
 func (*NackResponse) ProtoMessage()    {}
 func (*NackResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{9}
+	return fileDescriptor_endpoint_b183178546864154, []int{11}
 }
 func (m *NackResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NackResponse.Unmarshal(m, b)
@@ -438,7 +540,7 @@ func (m *CloseRequest) Reset()         { *m = CloseRequest{} }
 func (m *CloseRequest) String() string { return proto.CompactTextString(m) }
 func (*CloseRequest) ProtoMessage()    {}
 func (*CloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{10}
+	return fileDescriptor_endpoint_b183178546864154, []int{12}
 }
 func (m *CloseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CloseRequest.Unmarshal(m, b)
@@ -475,7 +577,7 @@ func (m *CloseResponse) Reset()         { *m = CloseResponse{} }
 func (m *CloseResponse) String() string { return proto.CompactTextString(m) }
 func (*CloseResponse) ProtoMessage()    {}
 func (*CloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endpoint_7600ba83ec0d42ef, []int{11}
+	return fileDescriptor_endpoint_b183178546864154, []int{13}
 }
 func (m *CloseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CloseResponse.Unmarshal(m, b)
@@ -500,6 +602,8 @@ func init() {
 	proto.RegisterType((*InitEndpointResponse)(nil), "proto.InitEndpointResponse")
 	proto.RegisterType((*SendRequest)(nil), "proto.SendRequest")
 	proto.RegisterType((*SendResponse)(nil), "proto.SendResponse")
+	proto.RegisterType((*SendBatchRequest)(nil), "proto.SendBatchRequest")
+	proto.RegisterType((*SendBatchResponse)(nil), "proto.SendBatchResponse")
 	proto.RegisterType((*ReceiveRequest)(nil), "proto.ReceiveRequest")
 	proto.RegisterType((*ReceiveResponse)(nil), "proto.ReceiveResponse")
 	proto.RegisterType((*AckRequest)(nil), "proto.AckRequest")
@@ -522,12 +626,17 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type EndpointClient interface {
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
 	Init(ctx context.Context, in *InitEndpointRequest, opts ...grpc.CallOption) (*InitEndpointResponse, error)
 	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	SendBatch(ctx context.Context, in *SendBatchRequest, opts ...grpc.CallOption) (*SendBatchResponse, error)
 	Receive(ctx context.Context, in *ReceiveRequest, opts ...grpc.CallOption) (*ReceiveResponse, error)
+	ReceiveStream(ctx context.Context, in *ReceiveRequest, opts ...grpc.CallOption) (Endpoint_ReceiveStreamClient, error)
 	Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error)
 	Nack(ctx context.Context, in *NackRequest, opts ...grpc.CallOption) (*NackResponse, error)
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
+	UploadBody(ctx context.Context, opts ...grpc.CallOption) (Endpoint_UploadBodyClient, error)
+	DownloadBody(ctx context.Context, in *DownloadBodyRequest, opts ...grpc.CallOption) (Endpoint_DownloadBodyClient, error)
 }
 
 type endpointClient struct {
@@ -538,6 +647,15 @@ func NewEndpointClient(cc *grpc.ClientConn) EndpointClient {
 	return &endpointClient{cc}
 }
 
+func (c *endpointClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, "/proto.Endpoint/Describe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *endpointClient) Init(ctx context.Context, in *InitEndpointRequest, opts ...grpc.CallOption) (*InitEndpointResponse, error) {
 	out := new(InitEndpointResponse)
 	err := c.cc.Invoke(ctx, "/proto.Endpoint/Init", in, out, opts...)
@@ -556,6 +674,15 @@ func (c *endpointClient) Send(ctx context.Context, in *SendRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *endpointClient) SendBatch(ctx context.Context, in *SendBatchRequest, opts ...grpc.CallOption) (*SendBatchResponse, error) {
+	out := new(SendBatchResponse)
+	err := c.cc.Invoke(ctx, "/proto.Endpoint/SendBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *endpointClient) Receive(ctx context.Context, in *ReceiveRequest, opts ...grpc.CallOption) (*ReceiveResponse, error) {
 	out := new(ReceiveResponse)
 	err := c.cc.Invoke(ctx, "/proto.Endpoint/Receive", in, out, opts...)
@@ -565,6 +692,38 @@ func (c *endpointClient) Receive(ctx context.Context, in *ReceiveRequest, opts .
 	return out, nil
 }
 
+func (c *endpointClient) ReceiveStream(ctx context.Context, in *ReceiveRequest, opts ...grpc.CallOption) (Endpoint_ReceiveStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Endpoint_serviceDesc.Streams[0], "/proto.Endpoint/ReceiveStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &endpointReceiveStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Endpoint_ReceiveStreamClient interface {
+	Recv() (*ReceiveResponse, error)
+	grpc.ClientStream
+}
+
+type endpointReceiveStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *endpointReceiveStreamClient) Recv() (*ReceiveResponse, error) {
+	m := new(ReceiveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *endpointClient) Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error) {
 	out := new(AckResponse)
 	err := c.cc.Invoke(ctx, "/proto.Endpoint/Ack", in, out, opts...)
@@ -592,20 +751,109 @@ func (c *endpointClient) Close(ctx context.Context, in *CloseRequest, opts ...gr
 	return out, nil
 }
 
+func (c *endpointClient) UploadBody(ctx context.Context, opts ...grpc.CallOption) (Endpoint_UploadBodyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Endpoint_serviceDesc.Streams[1], "/proto.Endpoint/UploadBody", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &endpointUploadBodyClient{stream}
+	return x, nil
+}
+
+type Endpoint_UploadBodyClient interface {
+	Send(*BodyChunk) error
+	CloseAndRecv() (*UploadBodyResponse, error)
+	grpc.ClientStream
+}
+
+type endpointUploadBodyClient struct {
+	grpc.ClientStream
+}
+
+func (x *endpointUploadBodyClient) Send(m *BodyChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *endpointUploadBodyClient) CloseAndRecv() (*UploadBodyResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBodyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *endpointClient) DownloadBody(ctx context.Context, in *DownloadBodyRequest, opts ...grpc.CallOption) (Endpoint_DownloadBodyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Endpoint_serviceDesc.Streams[2], "/proto.Endpoint/DownloadBody", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &endpointDownloadBodyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Endpoint_DownloadBodyClient interface {
+	Recv() (*BodyChunk, error)
+	grpc.ClientStream
+}
+
+type endpointDownloadBodyClient struct {
+	grpc.ClientStream
+}
+
+func (x *endpointDownloadBodyClient) Recv() (*BodyChunk, error) {
+	m := new(BodyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EndpointServer is the server API for Endpoint service.
 type EndpointServer interface {
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
 	Init(context.Context, *InitEndpointRequest) (*InitEndpointResponse, error)
 	Send(context.Context, *SendRequest) (*SendResponse, error)
+	SendBatch(context.Context, *SendBatchRequest) (*SendBatchResponse, error)
 	Receive(context.Context, *ReceiveRequest) (*ReceiveResponse, error)
+	ReceiveStream(*ReceiveRequest, Endpoint_ReceiveStreamServer) error
 	Ack(context.Context, *AckRequest) (*AckResponse, error)
 	Nack(context.Context, *NackRequest) (*NackResponse, error)
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
+	UploadBody(Endpoint_UploadBodyServer) error
+	DownloadBody(*DownloadBodyRequest, Endpoint_DownloadBodyServer) error
 }
 
 func RegisterEndpointServer(s *grpc.Server, srv EndpointServer) {
 	s.RegisterService(&_Endpoint_serviceDesc, srv)
 }
 
+func _Endpoint_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Endpoint/Describe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Endpoint_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InitEndpointRequest)
 	if err := dec(in); err != nil {
@@ -642,6 +890,24 @@ func _Endpoint_Send_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Endpoint_SendBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointServer).SendBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Endpoint/SendBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointServer).SendBatch(ctx, req.(*SendBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Endpoint_Receive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReceiveRequest)
 	if err := dec(in); err != nil {
@@ -660,6 +926,27 @@ func _Endpoint_Receive_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Endpoint_ReceiveStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReceiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EndpointServer).ReceiveStream(m, &endpointReceiveStreamServer{stream})
+}
+
+type Endpoint_ReceiveStreamServer interface {
+	Send(*ReceiveResponse) error
+	grpc.ServerStream
+}
+
+type endpointReceiveStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *endpointReceiveStreamServer) Send(m *ReceiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Endpoint_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AckRequest)
 	if err := dec(in); err != nil {
@@ -714,10 +1001,61 @@ func _Endpoint_Close_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Endpoint_UploadBody_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EndpointServer).UploadBody(&endpointUploadBodyServer{stream})
+}
+
+type Endpoint_UploadBodyServer interface {
+	SendAndClose(*UploadBodyResponse) error
+	Recv() (*BodyChunk, error)
+	grpc.ServerStream
+}
+
+type endpointUploadBodyServer struct {
+	grpc.ServerStream
+}
+
+func (x *endpointUploadBodyServer) SendAndClose(m *UploadBodyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *endpointUploadBodyServer) Recv() (*BodyChunk, error) {
+	m := new(BodyChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Endpoint_DownloadBody_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBodyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EndpointServer).DownloadBody(m, &endpointDownloadBodyServer{stream})
+}
+
+type Endpoint_DownloadBodyServer interface {
+	Send(*BodyChunk) error
+	grpc.ServerStream
+}
+
+type endpointDownloadBodyServer struct {
+	grpc.ServerStream
+}
+
+func (x *endpointDownloadBodyServer) Send(m *BodyChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Endpoint_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Endpoint",
 	HandlerType: (*EndpointServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _Endpoint_Describe_Handler,
+		},
 		{
 			MethodName: "Init",
 			Handler:    _Endpoint_Init_Handler,
@@ -726,6 +1064,10 @@ var _Endpoint_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Send",
 			Handler:    _Endpoint_Send_Handler,
 		},
+		{
+			MethodName: "SendBatch",
+			Handler:    _Endpoint_SendBatch_Handler,
+		},
 		{
 			MethodName: "Receive",
 			Handler:    _Endpoint_Receive_Handler,
@@ -743,38 +1085,68 @@ var _Endpoint_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Endpoint_Close_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReceiveStream",
+			Handler:       _Endpoint_ReceiveStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadBody",
+			Handler:       _Endpoint_UploadBody_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadBody",
+			Handler:       _Endpoint_DownloadBody_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "endpoint.proto",
 }
 
-func init() { proto.RegisterFile("endpoint.proto", fileDescriptor_endpoint_7600ba83ec0d42ef) }
-
-var fileDescriptor_endpoint_7600ba83ec0d42ef = []byte{
-	// 402 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x4d, 0x6f, 0xda, 0x40,
-	0x10, 0x15, 0xdf, 0x74, 0x6c, 0x43, 0xbb, 0x50, 0x84, 0xcc, 0xa1, 0xc8, 0x27, 0x0e, 0x15, 0x08,
-	0xaa, 0x4a, 0xbd, 0x55, 0x56, 0x55, 0xa9, 0x3d, 0x84, 0x83, 0x89, 0x72, 0x45, 0xc6, 0x9e, 0x58,
-	0x16, 0x89, 0xed, 0xec, 0x1a, 0xfe, 0x63, 0xfe, 0x55, 0xe4, 0xdd, 0xb1, 0xb1, 0x23, 0x90, 0x2c,
-	0xe5, 0x64, 0xef, 0xdb, 0x99, 0x79, 0x6f, 0xdf, 0x3c, 0x18, 0x60, 0xe4, 0x27, 0x71, 0x18, 0xa5,
-	0xcb, 0x84, 0xc7, 0x69, 0xcc, 0x3a, 0xf2, 0x63, 0x1a, 0xcf, 0x28, 0x84, 0x1b, 0xa0, 0x42, 0xad,
-	0x2d, 0x8c, 0xfe, 0x47, 0x61, 0xfa, 0x97, 0x6a, 0x1d, 0x7c, 0x39, 0xa1, 0x48, 0xd9, 0x37, 0xd0,
-	0x44, 0x7a, 0x3a, 0xec, 0x05, 0xf2, 0x33, 0xf2, 0x69, 0x63, 0xde, 0x58, 0x18, 0x0e, 0x64, 0xd0,
-	0x4e, 0x22, 0x6c, 0x02, 0x5d, 0x2f, 0x8e, 0x1e, 0xc3, 0x60, 0xda, 0x9c, 0x37, 0x16, 0xba, 0x43,
-	0x27, 0x6b, 0x02, 0xe3, 0xea, 0x3c, 0x91, 0xc4, 0x91, 0x40, 0x6b, 0x0f, 0xda, 0x0e, 0x23, 0xbf,
-	0xf6, 0xfc, 0x15, 0xf4, 0x48, 0xa8, 0x24, 0xd0, 0x36, 0x5f, 0x95, 0xe0, 0xa5, 0xed, 0xbb, 0x49,
-	0x8a, 0xfc, 0x4e, 0x5d, 0x3a, 0x79, 0x95, 0x65, 0x83, 0xae, 0x08, 0x14, 0x21, 0x5b, 0x43, 0x9f,
-	0xd3, 0xbf, 0x1c, 0x7f, 0x73, 0x42, 0x51, 0x66, 0xad, 0x61, 0xe0, 0xa0, 0x87, 0xe1, 0x19, 0xeb,
-	0xca, 0xb4, 0xfe, 0xc1, 0xb0, 0x68, 0x21, 0xe2, 0x9f, 0x17, 0xe5, 0x8a, 0x77, 0x46, 0xbc, 0xf7,
-	0x6e, 0x10, 0xa0, 0x7f, 0x4b, 0x3f, 0x07, 0xb0, 0xbd, 0x63, 0x6d, 0x7f, 0x3e, 0x43, 0x2b, 0x75,
-	0x95, 0xf9, 0x6d, 0x27, 0xfb, 0xad, 0x3c, 0xb8, 0x55, 0xef, 0xc1, 0x06, 0x68, 0x92, 0x93, 0x8e,
-	0x0f, 0xa0, 0x6d, 0xdd, 0x0f, 0x69, 0x18, 0x43, 0x07, 0x39, 0x8f, 0xb9, 0x14, 0xf0, 0xc9, 0x51,
-	0x07, 0x6b, 0x00, 0xba, 0x9a, 0x4b, 0x3c, 0x2b, 0xd0, 0xff, 0x3c, 0xc5, 0xa2, 0xbe, 0xcb, 0x43,
-	0x30, 0xa8, 0x41, 0x4d, 0xd8, 0xbc, 0x36, 0xa1, 0x9f, 0x47, 0x8c, 0xfd, 0x86, 0x76, 0x16, 0x39,
-	0x66, 0xd2, 0x73, 0xaf, 0xe4, 0xd9, 0x9c, 0x5d, 0xbd, 0xa3, 0x8d, 0xad, 0xa0, 0x9d, 0x45, 0x87,
-	0x31, 0x2a, 0x2a, 0x05, 0xd5, 0x1c, 0x55, 0x30, 0x6a, 0xf8, 0x05, 0x3d, 0xda, 0x3a, 0xcb, 0x3d,
-	0xae, 0x06, 0xc7, 0x9c, 0xbc, 0x87, 0xa9, 0xf3, 0x3b, 0xb4, 0x6c, 0xef, 0xc8, 0xbe, 0xe4, 0x9b,
-	0x29, 0xdc, 0x36, 0x59, 0x19, 0xba, 0x08, 0xcb, 0x8c, 0x2b, 0x84, 0x95, 0xb6, 0x53, 0x08, 0x2b,
-	0x3b, 0xcb, 0x36, 0xd0, 0x91, 0x46, 0xb1, 0xfc, 0xb6, 0xec, 0xb3, 0x39, 0xae, 0x82, 0xaa, 0xe7,
-	0xd0, 0x95, 0xe0, 0x8f, 0xb7, 0x00, 0x00, 0x00, 0xff, 0xff, 0x70, 0x34, 0x4b, 0xa4, 0x30, 0x04,
+func init() { proto.RegisterFile("endpoint.proto", fileDescriptor_endpoint_b183178546864154) }
+
+var fileDescriptor_endpoint_b183178546864154 = []byte{
+	// 626 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcb, 0x4f, 0xdb, 0x4e,
+	0x10, 0x96, 0x49, 0x02, 0x61, 0x9c, 0x84, 0xb0, 0xf0, 0x0b, 0xfe, 0x2d, 0x87, 0x46, 0x3e, 0x45,
+	0x6a, 0xc5, 0xab, 0x42, 0xaa, 0x84, 0x44, 0x1b, 0x1e, 0x52, 0x39, 0x94, 0x83, 0x69, 0xcf, 0xd1,
+	0xc6, 0x5e, 0x82, 0x45, 0xf0, 0xa6, 0xbb, 0x1b, 0x2a, 0xd4, 0x73, 0x8f, 0xfd, 0x9f, 0x2b, 0xaf,
+	0x67, 0x1d, 0xbb, 0xa1, 0xc8, 0x55, 0x4f, 0xd9, 0xf9, 0xe6, 0xf5, 0x65, 0xe6, 0x1b, 0x43, 0x87,
+	0x27, 0xd1, 0x4c, 0xc4, 0x89, 0xde, 0x9b, 0x49, 0xa1, 0x05, 0x69, 0x98, 0x1f, 0xda, 0x7e, 0xe0,
+	0x4a, 0xb1, 0x09, 0xcf, 0x50, 0xea, 0x72, 0x29, 0x85, 0xb4, 0xc6, 0x98, 0xe9, 0xf0, 0x0e, 0x8d,
+	0x4e, 0xc4, 0x55, 0x28, 0xe3, 0x31, 0x46, 0xfa, 0xdf, 0x61, 0xeb, 0x2a, 0x89, 0xf5, 0x25, 0x56,
+	0x0d, 0xf8, 0xd7, 0x39, 0x57, 0x9a, 0xbc, 0x02, 0x57, 0xe9, 0xf9, 0x78, 0xa4, 0xb8, 0x7c, 0xe4,
+	0xd2, 0x73, 0xfa, 0xce, 0xa0, 0x1d, 0x40, 0x0a, 0xdd, 0x18, 0x84, 0xf4, 0x60, 0x35, 0x14, 0xc9,
+	0x6d, 0x3c, 0xf1, 0x56, 0xfa, 0xce, 0xa0, 0x15, 0xa0, 0x45, 0x5e, 0xc3, 0xe6, 0x9d, 0x50, 0x7a,
+	0x14, 0xb2, 0x19, 0x1b, 0xc7, 0xd3, 0x58, 0xc7, 0x5c, 0x79, 0xb5, 0x7e, 0x6d, 0xb0, 0x1e, 0x74,
+	0x53, 0xc7, 0x79, 0x01, 0xf7, 0x7b, 0xb0, 0x5d, 0x6e, 0xae, 0x66, 0x22, 0x51, 0xdc, 0x1f, 0x81,
+	0x7b, 0xc3, 0x93, 0xa8, 0x32, 0x99, 0x7d, 0x58, 0xc3, 0xff, 0x6f, 0xd8, 0xb8, 0x47, 0xff, 0x65,
+	0xff, 0x6e, 0x6f, 0x18, 0xb1, 0x99, 0xe6, 0xf2, 0x53, 0xe6, 0x0c, 0x6c, 0x94, 0x3f, 0x84, 0x56,
+	0xd6, 0x20, 0x6b, 0x48, 0x0e, 0xa1, 0x29, 0xf1, 0xed, 0x39, 0x2f, 0x55, 0xc8, 0xc3, 0xfc, 0x5b,
+	0xe8, 0xa6, 0x25, 0xce, 0xd2, 0xd9, 0x56, 0x26, 0x7a, 0x08, 0x4d, 0xa4, 0xa0, 0xbc, 0x95, 0x7e,
+	0xed, 0x85, 0x3e, 0x36, 0xcc, 0xbf, 0x84, 0xcd, 0x42, 0x1f, 0xe4, 0x7b, 0x00, 0x6b, 0x92, 0xab,
+	0xf9, 0x54, 0x2b, 0xcf, 0x31, 0x65, 0x7a, 0x58, 0xc6, 0x84, 0x5d, 0x69, 0xfe, 0x10, 0x18, 0x77,
+	0x60, 0xc3, 0xfc, 0x43, 0xe8, 0x04, 0x3c, 0xe4, 0xf1, 0x23, 0xaf, 0x4a, 0xd6, 0xff, 0x08, 0x1b,
+	0x79, 0x0a, 0xf6, 0x3d, 0x5e, 0x0c, 0x3a, 0x1b, 0xd3, 0x2e, 0xf6, 0xfd, 0xcc, 0x26, 0x13, 0x1e,
+	0xfd, 0x69, 0xdc, 0x12, 0x60, 0x18, 0xde, 0x57, 0x9e, 0x52, 0x17, 0x6a, 0x9a, 0x65, 0xc2, 0xaa,
+	0x07, 0xe9, 0xb3, 0xb4, 0x9f, 0x5a, 0xb5, 0xfd, 0xb4, 0xc1, 0x35, 0x3d, 0xd1, 0xfc, 0xe9, 0x80,
+	0x7b, 0xcd, 0xfe, 0x89, 0xc4, 0x36, 0x34, 0xcc, 0x59, 0x19, 0x06, 0xeb, 0x41, 0x66, 0x90, 0x63,
+	0x68, 0x99, 0xc7, 0x28, 0xe2, 0x9a, 0xc5, 0x53, 0xaf, 0x6e, 0xe8, 0x11, 0xa4, 0x77, 0x99, 0xba,
+	0x2e, 0x8c, 0x27, 0x70, 0xf9, 0xc2, 0xf0, 0x3b, 0xd0, 0xba, 0x66, 0x05, 0x7e, 0xfb, 0xd0, 0x3a,
+	0x9f, 0x0a, 0x55, 0x7d, 0x3b, 0x1b, 0xd0, 0xc6, 0x84, 0xac, 0xc2, 0xd1, 0x8f, 0x06, 0x34, 0xed,
+	0x25, 0x91, 0x13, 0x68, 0x5e, 0xe0, 0xa1, 0x13, 0xab, 0x0d, 0x0b, 0x60, 0x0b, 0xba, 0xb3, 0x84,
+	0xe3, 0x96, 0xdf, 0x43, 0x3d, 0x3d, 0x4b, 0x42, 0x31, 0xe0, 0x99, 0x0f, 0x04, 0xdd, 0x7d, 0xd6,
+	0x87, 0x05, 0xf6, 0xa1, 0x9e, 0x6a, 0x96, 0xd8, 0x29, 0x14, 0x8e, 0x99, 0x6e, 0x95, 0x30, 0x4c,
+	0x38, 0x85, 0xf5, 0x5c, 0xe4, 0x64, 0xa7, 0x10, 0x51, 0x3c, 0x2f, 0xea, 0x2d, 0x3b, 0x30, 0xff,
+	0x1d, 0xac, 0xa1, 0x54, 0x89, 0x15, 0x46, 0x59, 0xed, 0xb4, 0xf7, 0x3b, 0x8c, 0x99, 0x1f, 0xa0,
+	0x8d, 0xd0, 0x8d, 0x96, 0x9c, 0x3d, 0xfc, 0x65, 0xfe, 0x81, 0x43, 0xde, 0x40, 0x6d, 0x18, 0xde,
+	0x93, 0x4d, 0x2b, 0xc8, 0x5c, 0x63, 0x94, 0x14, 0xa1, 0xc5, 0x68, 0xd2, 0xbd, 0xe7, 0xa3, 0x29,
+	0x68, 0x92, 0x6e, 0x95, 0x30, 0x4c, 0x38, 0x82, 0x86, 0xd9, 0x33, 0xb1, 0xde, 0xa2, 0x4c, 0xe8,
+	0x76, 0x19, 0xc4, 0x9c, 0x13, 0x80, 0x2f, 0xb3, 0xa9, 0x60, 0xd1, 0x99, 0x88, 0x9e, 0x48, 0xd7,
+	0x7e, 0x1b, 0x44, 0xf4, 0x74, 0x7e, 0x37, 0x4f, 0xee, 0xe9, 0xff, 0x88, 0x2c, 0x82, 0x6c, 0xea,
+	0xc0, 0x21, 0xa7, 0xd0, 0xba, 0x10, 0xdf, 0x92, 0x3c, 0xdd, 0xaa, 0xa0, 0x08, 0xda, 0xf6, 0x4b,
+	0xa5, 0x0f, 0x9c, 0xf1, 0xaa, 0x81, 0xde, 0xfe, 0x1a, 0x00, 0xec, 0xcc, 0xdd, 0xf4, 0xaa, 0x06,
 	0x00, 0x00,
 }
@@ -0,0 +1,128 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: error.proto
+
+package proto
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import duration "github.com/golang/protobuf/ptypes/duration"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ErrorDetail is attached to the gRPC status of every failed call whose error is an adapter.Error.
+type ErrorDetail struct {
+	Code       string             `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message    string             `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Retryable  bool               `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	RetryAfter *duration.Duration `protobuf:"bytes,4,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+	Details    map[string]string  `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the message of the error that the detail was taken from, which differs from the one of the detail if that error
+	// wraps it
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErrorDetail) Reset()         { *m = ErrorDetail{} }
+func (m *ErrorDetail) String() string { return proto.CompactTextString(m) }
+func (*ErrorDetail) ProtoMessage()    {}
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_error_26fe401b431543b0, []int{0}
+}
+func (m *ErrorDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorDetail.Unmarshal(m, b)
+}
+func (m *ErrorDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorDetail.Marshal(b, m, deterministic)
+}
+func (dst *ErrorDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorDetail.Merge(dst, src)
+}
+func (m *ErrorDetail) XXX_Size() int {
+	return xxx_messageInfo_ErrorDetail.Size(m)
+}
+func (m *ErrorDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorDetail proto.InternalMessageInfo
+
+func (m *ErrorDetail) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetRetryable() bool {
+	if m != nil {
+		return m.Retryable
+	}
+	return false
+}
+
+func (m *ErrorDetail) GetRetryAfter() *duration.Duration {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return nil
+}
+
+func (m *ErrorDetail) GetDetails() map[string]string {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *ErrorDetail) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ErrorDetail)(nil), "proto.ErrorDetail")
+	proto.RegisterMapType((map[string]string)(nil), "proto.ErrorDetail.DetailsEntry")
+}
+
+func init() { proto.RegisterFile("error.proto", fileDescriptor_error_26fe401b431543b0) }
+
+var fileDescriptor_error_26fe401b431543b0 = []byte{
+	// 242 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xc1, 0x4a, 0xc3, 0x40,
+	0x10, 0x86, 0xd9, 0xa6, 0x69, 0xed, 0xac, 0x07, 0x19, 0x3c, 0xac, 0x45, 0x34, 0x78, 0xca, 0x69,
+	0x0b, 0xf5, 0xa2, 0xb9, 0x09, 0xed, 0x0b, 0xe4, 0x05, 0x64, 0x63, 0xa6, 0xa1, 0x18, 0xbb, 0x32,
+	0xd9, 0x08, 0x79, 0x1d, 0x9f, 0x54, 0x32, 0xdb, 0x60, 0x4f, 0x33, 0xff, 0xf0, 0x0d, 0xfb, 0xcd,
+	0x82, 0x26, 0x66, 0xcf, 0xf6, 0x9b, 0x7d, 0xf0, 0x98, 0x4a, 0x59, 0x3f, 0x34, 0xde, 0x37, 0x2d,
+	0x6d, 0x24, 0x55, 0xfd, 0x61, 0x53, 0xf7, 0xec, 0xc2, 0xd1, 0x9f, 0x22, 0xf6, 0xf4, 0x3b, 0x03,
+	0xbd, 0x1f, 0xd7, 0x76, 0x14, 0xdc, 0xb1, 0x45, 0x84, 0xf9, 0x87, 0xaf, 0xc9, 0xa8, 0x4c, 0xe5,
+	0xab, 0x52, 0x7a, 0x34, 0xb0, 0xfc, 0xa2, 0xae, 0x73, 0x0d, 0x99, 0x99, 0x8c, 0xa7, 0x88, 0xf7,
+	0xb0, 0x62, 0x0a, 0x3c, 0xb8, 0xaa, 0x25, 0x93, 0x64, 0x2a, 0xbf, 0x2a, 0xff, 0x07, 0x58, 0x80,
+	0x96, 0xf0, 0xee, 0x0e, 0x81, 0xd8, 0xcc, 0x33, 0x95, 0xeb, 0xed, 0x9d, 0x8d, 0x46, 0x76, 0x32,
+	0xb2, 0xbb, 0xb3, 0x51, 0x09, 0x42, 0xbf, 0x8d, 0x30, 0xbe, 0xc2, 0xb2, 0x16, 0xa3, 0xce, 0xa4,
+	0x59, 0x92, 0xeb, 0xed, 0x63, 0x5c, 0xb0, 0x17, 0xb2, 0x36, 0x96, 0x6e, 0x7f, 0x0a, 0x3c, 0x94,
+	0x13, 0x8f, 0xb7, 0x90, 0xca, 0x47, 0x98, 0x85, 0xc8, 0xc6, 0xb0, 0x2e, 0xe0, 0xfa, 0x12, 0xc7,
+	0x1b, 0x48, 0x3e, 0x69, 0x38, 0xdf, 0x39, 0xb6, 0xe3, 0xde, 0x8f, 0x6b, 0xfb, 0xe9, 0xc8, 0x18,
+	0x8a, 0xd9, 0x8b, 0xaa, 0x16, 0xf2, 0xf4, 0xf3, 0xdf, 0x00, 0x7a, 0x2f, 0x06, 0xb2, 0x61, 0x01,
+	0x00, 0x00,
+}
@@ -6,6 +6,7 @@ package proto
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -19,18 +20,24 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type AdapterMessage struct {
-	Body                 []byte          `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
-	Attributes           map[string]bool `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Body []byte `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// the bool-valued attributes, also present in typed_attributes, for plugins that predate typed attributes
+	Attributes      map[string]bool            `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Id              string                     `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Headers         map[string]string          `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TypedAttributes map[string]*AttributeValue `protobuf:"bytes,5,rep,name=typed_attributes,json=typedAttributes,proto3" json:"typed_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// set instead of body if the body was too large to be part of the call, and has been streamed in chunks instead
+	BodyRef              string   `protobuf:"bytes,6,opt,name=body_ref,json=bodyRef,proto3" json:"body_ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AdapterMessage) Reset()         { *m = AdapterMessage{} }
 func (m *AdapterMessage) String() string { return proto.CompactTextString(m) }
 func (*AdapterMessage) ProtoMessage()    {}
 func (*AdapterMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_a2ad2a18804d23a6, []int{0}
+	return fileDescriptor_message_3feda47298a4ee15, []int{0}
 }
 func (m *AdapterMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdapterMessage.Unmarshal(m, b)
@@ -64,6 +71,292 @@ func (m *AdapterMessage) GetAttributes() map[string]bool {
 	return nil
 }
 
+func (m *AdapterMessage) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AdapterMessage) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *AdapterMessage) GetTypedAttributes() map[string]*AttributeValue {
+	if m != nil {
+		return m.TypedAttributes
+	}
+	return nil
+}
+
+func (m *AdapterMessage) GetBodyRef() string {
+	if m != nil {
+		return m.BodyRef
+	}
+	return ""
+}
+
+type AttributeValue struct {
+	// Types that are valid to be assigned to Value:
+	//	*AttributeValue_StringValue
+	//	*AttributeValue_IntValue
+	//	*AttributeValue_DoubleValue
+	//	*AttributeValue_BoolValue
+	//	*AttributeValue_BytesValue
+	//	*AttributeValue_TimestampValue
+	Value                isAttributeValue_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *AttributeValue) Reset()         { *m = AttributeValue{} }
+func (m *AttributeValue) String() string { return proto.CompactTextString(m) }
+func (*AttributeValue) ProtoMessage()    {}
+func (*AttributeValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_3feda47298a4ee15, []int{1}
+}
+func (m *AttributeValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttributeValue.Unmarshal(m, b)
+}
+func (m *AttributeValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttributeValue.Marshal(b, m, deterministic)
+}
+func (dst *AttributeValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttributeValue.Merge(dst, src)
+}
+func (m *AttributeValue) XXX_Size() int {
+	return xxx_messageInfo_AttributeValue.Size(m)
+}
+func (m *AttributeValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttributeValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttributeValue proto.InternalMessageInfo
+
+type isAttributeValue_Value interface {
+	isAttributeValue_Value()
+}
+
+type AttributeValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type AttributeValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type AttributeValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,3,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type AttributeValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,4,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type AttributeValue_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,5,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type AttributeValue_TimestampValue struct {
+	TimestampValue *timestamp.Timestamp `protobuf:"bytes,6,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+func (*AttributeValue_StringValue) isAttributeValue_Value() {}
+
+func (*AttributeValue_IntValue) isAttributeValue_Value() {}
+
+func (*AttributeValue_DoubleValue) isAttributeValue_Value() {}
+
+func (*AttributeValue_BoolValue) isAttributeValue_Value() {}
+
+func (*AttributeValue_BytesValue) isAttributeValue_Value() {}
+
+func (*AttributeValue_TimestampValue) isAttributeValue_Value() {}
+
+func (m *AttributeValue) GetValue() isAttributeValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *AttributeValue) GetStringValue() string {
+	if x, ok := m.GetValue().(*AttributeValue_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *AttributeValue) GetIntValue() int64 {
+	if x, ok := m.GetValue().(*AttributeValue_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (m *AttributeValue) GetDoubleValue() float64 {
+	if x, ok := m.GetValue().(*AttributeValue_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (m *AttributeValue) GetBoolValue() bool {
+	if x, ok := m.GetValue().(*AttributeValue_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (m *AttributeValue) GetBytesValue() []byte {
+	if x, ok := m.GetValue().(*AttributeValue_BytesValue); ok {
+		return x.BytesValue
+	}
+	return nil
+}
+
+func (m *AttributeValue) GetTimestampValue() *timestamp.Timestamp {
+	if x, ok := m.GetValue().(*AttributeValue_TimestampValue); ok {
+		return x.TimestampValue
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*AttributeValue) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _AttributeValue_OneofMarshaler, _AttributeValue_OneofUnmarshaler, _AttributeValue_OneofSizer, []interface{}{
+		(*AttributeValue_StringValue)(nil),
+		(*AttributeValue_IntValue)(nil),
+		(*AttributeValue_DoubleValue)(nil),
+		(*AttributeValue_BoolValue)(nil),
+		(*AttributeValue_BytesValue)(nil),
+		(*AttributeValue_TimestampValue)(nil),
+	}
+}
+
+func _AttributeValue_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*AttributeValue)
+	// value
+	switch x := m.Value.(type) {
+	case *AttributeValue_StringValue:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.StringValue)
+	case *AttributeValue_IntValue:
+		b.EncodeVarint(2<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.IntValue))
+	case *AttributeValue_DoubleValue:
+		b.EncodeVarint(3<<3 | proto.WireFixed64)
+		b.EncodeFixed64(math.Float64bits(x.DoubleValue))
+	case *AttributeValue_BoolValue:
+		t := uint64(0)
+		if x.BoolValue {
+			t = 1
+		}
+		b.EncodeVarint(4<<3 | proto.WireVarint)
+		b.EncodeVarint(t)
+	case *AttributeValue_BytesValue:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		b.EncodeRawBytes(x.BytesValue)
+	case *AttributeValue_TimestampValue:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TimestampValue); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("AttributeValue.Value has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _AttributeValue_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*AttributeValue)
+	switch tag {
+	case 1: // value.string_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Value = &AttributeValue_StringValue{x}
+		return true, err
+	case 2: // value.int_value
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Value = &AttributeValue_IntValue{int64(x)}
+		return true, err
+	case 3: // value.double_value
+		if wire != proto.WireFixed64 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed64()
+		m.Value = &AttributeValue_DoubleValue{math.Float64frombits(x)}
+		return true, err
+	case 4: // value.bool_value
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Value = &AttributeValue_BoolValue{x != 0}
+		return true, err
+	case 5: // value.bytes_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeRawBytes(true)
+		m.Value = &AttributeValue_BytesValue{x}
+		return true, err
+	case 6: // value.timestamp_value
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(timestamp.Timestamp)
+		err := b.DecodeMessage(msg)
+		m.Value = &AttributeValue_TimestampValue{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _AttributeValue_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*AttributeValue)
+	// value
+	switch x := m.Value.(type) {
+	case *AttributeValue_StringValue:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.StringValue)))
+		n += len(x.StringValue)
+	case *AttributeValue_IntValue:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(x.IntValue))
+	case *AttributeValue_DoubleValue:
+		n += 1 // tag and wire
+		n += 8
+	case *AttributeValue_BoolValue:
+		n += 1 // tag and wire
+		n += 1
+	case *AttributeValue_BytesValue:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.BytesValue)))
+		n += len(x.BytesValue)
+	case *AttributeValue_TimestampValue:
+		s := proto.Size(x.TimestampValue)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
 type TaggedAdapterMessage struct {
 	Tag                  uint64          `protobuf:"varint,1,opt,name=Tag,proto3" json:"Tag,omitempty"`
 	Message              *AdapterMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -76,7 +369,7 @@ func (m *TaggedAdapterMessage) Reset()         { *m = TaggedAdapterMessage{} }
 func (m *TaggedAdapterMessage) String() string { return proto.CompactTextString(m) }
 func (*TaggedAdapterMessage) ProtoMessage()    {}
 func (*TaggedAdapterMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_a2ad2a18804d23a6, []int{1}
+	return fileDescriptor_message_3feda47298a4ee15, []int{2}
 }
 func (m *TaggedAdapterMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TaggedAdapterMessage.Unmarshal(m, b)
@@ -110,27 +403,167 @@ func (m *TaggedAdapterMessage) GetMessage() *AdapterMessage {
 	return nil
 }
 
+// BodyChunk is a part of a body that is too large to be part of a call
+type BodyChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BodyChunk) Reset()         { *m = BodyChunk{} }
+func (m *BodyChunk) String() string { return proto.CompactTextString(m) }
+func (*BodyChunk) ProtoMessage()    {}
+func (*BodyChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_3feda47298a4ee15, []int{3}
+}
+func (m *BodyChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyChunk.Unmarshal(m, b)
+}
+func (m *BodyChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyChunk.Marshal(b, m, deterministic)
+}
+func (dst *BodyChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyChunk.Merge(dst, src)
+}
+func (m *BodyChunk) XXX_Size() int {
+	return xxx_messageInfo_BodyChunk.Size(m)
+}
+func (m *BodyChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyChunk proto.InternalMessageInfo
+
+func (m *BodyChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type UploadBodyResponse struct {
+	BodyRef              string   `protobuf:"bytes,1,opt,name=body_ref,json=bodyRef,proto3" json:"body_ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadBodyResponse) Reset()         { *m = UploadBodyResponse{} }
+func (m *UploadBodyResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadBodyResponse) ProtoMessage()    {}
+func (*UploadBodyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_3feda47298a4ee15, []int{4}
+}
+func (m *UploadBodyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadBodyResponse.Unmarshal(m, b)
+}
+func (m *UploadBodyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadBodyResponse.Marshal(b, m, deterministic)
+}
+func (dst *UploadBodyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadBodyResponse.Merge(dst, src)
+}
+func (m *UploadBodyResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadBodyResponse.Size(m)
+}
+func (m *UploadBodyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadBodyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadBodyResponse proto.InternalMessageInfo
+
+func (m *UploadBodyResponse) GetBodyRef() string {
+	if m != nil {
+		return m.BodyRef
+	}
+	return ""
+}
+
+type DownloadBodyRequest struct {
+	BodyRef              string   `protobuf:"bytes,1,opt,name=body_ref,json=bodyRef,proto3" json:"body_ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownloadBodyRequest) Reset()         { *m = DownloadBodyRequest{} }
+func (m *DownloadBodyRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadBodyRequest) ProtoMessage()    {}
+func (*DownloadBodyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_3feda47298a4ee15, []int{5}
+}
+func (m *DownloadBodyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadBodyRequest.Unmarshal(m, b)
+}
+func (m *DownloadBodyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadBodyRequest.Marshal(b, m, deterministic)
+}
+func (dst *DownloadBodyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadBodyRequest.Merge(dst, src)
+}
+func (m *DownloadBodyRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadBodyRequest.Size(m)
+}
+func (m *DownloadBodyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadBodyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadBodyRequest proto.InternalMessageInfo
+
+func (m *DownloadBodyRequest) GetBodyRef() string {
+	if m != nil {
+		return m.BodyRef
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AdapterMessage)(nil), "proto.AdapterMessage")
 	proto.RegisterMapType((map[string]bool)(nil), "proto.AdapterMessage.AttributesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "proto.AdapterMessage.HeadersEntry")
+	proto.RegisterMapType((map[string]*AttributeValue)(nil), "proto.AdapterMessage.TypedAttributesEntry")
+	proto.RegisterType((*AttributeValue)(nil), "proto.AttributeValue")
 	proto.RegisterType((*TaggedAdapterMessage)(nil), "proto.TaggedAdapterMessage")
+	proto.RegisterType((*BodyChunk)(nil), "proto.BodyChunk")
+	proto.RegisterType((*UploadBodyResponse)(nil), "proto.UploadBodyResponse")
+	proto.RegisterType((*DownloadBodyRequest)(nil), "proto.DownloadBodyRequest")
 }
 
-func init() { proto.RegisterFile("message.proto", fileDescriptor_message_a2ad2a18804d23a6) }
-
-var fileDescriptor_message_a2ad2a18804d23a6 = []byte{
-	// 199 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x4a, 0xab, 0x18,
-	0xb9, 0xf8, 0x1c, 0x53, 0x12, 0x0b, 0x4a, 0x52, 0x8b, 0x7c, 0x21, 0xf2, 0x42, 0x42, 0x5c, 0x2c,
-	0x49, 0xf9, 0x29, 0x95, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x60, 0xb6, 0x90, 0x2b, 0x17,
-	0x57, 0x62, 0x49, 0x49, 0x51, 0x66, 0x52, 0x69, 0x49, 0x6a, 0xb1, 0x04, 0x93, 0x02, 0xb3, 0x06,
-	0xb7, 0x91, 0x2a, 0xc4, 0x24, 0x3d, 0x54, 0xed, 0x7a, 0x8e, 0x70, 0x75, 0xae, 0x79, 0x25, 0x45,
-	0x95, 0x41, 0x48, 0x1a, 0xa5, 0x6c, 0xb9, 0xf8, 0xd1, 0xa4, 0x85, 0x04, 0xb8, 0x98, 0xb3, 0x53,
-	0x21, 0x96, 0x71, 0x06, 0x81, 0x98, 0x42, 0x22, 0x5c, 0xac, 0x65, 0x89, 0x39, 0xa5, 0xa9, 0x12,
-	0x4c, 0x0a, 0x8c, 0x1a, 0x1c, 0x41, 0x10, 0x8e, 0x15, 0x93, 0x05, 0xa3, 0x52, 0x24, 0x97, 0x48,
-	0x48, 0x62, 0x7a, 0x7a, 0x6a, 0x0a, 0x9a, 0x8b, 0x05, 0xb8, 0x98, 0x43, 0x12, 0xd3, 0xc1, 0x66,
-	0xb0, 0x04, 0x81, 0x98, 0x42, 0xfa, 0x5c, 0xec, 0x50, 0xef, 0x82, 0x4d, 0xe1, 0x36, 0x12, 0xc5,
-	0xea, 0xd8, 0x20, 0x98, 0xaa, 0x24, 0x36, 0xb0, 0xb4, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0xb0,
-	0x61, 0x52, 0xa2, 0x26, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("message.proto", fileDescriptor_message_3feda47298a4ee15) }
+
+var fileDescriptor_message_3feda47298a4ee15 = []byte{
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcf, 0x8b, 0xd3, 0x40,
+	0x14, 0xc7, 0x9b, 0xf4, 0x67, 0x5e, 0x6b, 0x5b, 0xc6, 0x15, 0x62, 0x41, 0x5a, 0x23, 0x42, 0x51,
+	0x48, 0x65, 0xbd, 0xc8, 0xa2, 0x87, 0xad, 0x16, 0x72, 0xf1, 0x32, 0x74, 0x05, 0x4f, 0x65, 0xc2,
+	0x4c, 0xb3, 0x61, 0xdb, 0x4c, 0xcc, 0x4c, 0x94, 0x1c, 0xfd, 0x03, 0xfc, 0x9f, 0x65, 0x66, 0x92,
+	0x6e, 0x52, 0x8a, 0x9e, 0xfa, 0xe6, 0xcd, 0xf7, 0xfb, 0xe9, 0x9b, 0x97, 0xf7, 0xe0, 0xc9, 0x91,
+	0x09, 0x41, 0x22, 0xe6, 0xa7, 0x19, 0x97, 0x1c, 0x75, 0xf5, 0xcf, 0x6c, 0x1e, 0x71, 0x1e, 0x1d,
+	0xd8, 0x4a, 0x9f, 0xc2, 0x7c, 0xbf, 0x92, 0xf1, 0x91, 0x09, 0x49, 0x8e, 0xa9, 0xd1, 0x79, 0xbf,
+	0x3b, 0x30, 0xbe, 0xa5, 0x24, 0x95, 0x2c, 0xfb, 0x6a, 0x00, 0x08, 0x41, 0x27, 0xe4, 0xb4, 0x70,
+	0xad, 0x85, 0xb5, 0x1c, 0x61, 0x1d, 0xa3, 0x0d, 0x00, 0x91, 0x32, 0x8b, 0xc3, 0x5c, 0x32, 0xe1,
+	0xda, 0x8b, 0xf6, 0x72, 0x78, 0xfd, 0xda, 0x20, 0xfc, 0xa6, 0xdd, 0xbf, 0x3d, 0xe9, 0x36, 0x89,
+	0xcc, 0x0a, 0x5c, 0x33, 0xa2, 0x31, 0xd8, 0x31, 0x75, 0xdb, 0x0b, 0x6b, 0xe9, 0x60, 0x3b, 0xa6,
+	0xe8, 0x23, 0xf4, 0xef, 0x19, 0xa1, 0x2c, 0x13, 0x6e, 0x47, 0x33, 0xbd, 0xcb, 0xcc, 0xc0, 0x88,
+	0x0c, 0xb0, 0xb2, 0xa0, 0x3b, 0x98, 0xca, 0x22, 0x65, 0x74, 0x57, 0x2b, 0xad, 0xab, 0x31, 0x6f,
+	0x2e, 0x63, 0xb6, 0x4a, 0x7d, 0x5e, 0xdf, 0x44, 0x36, 0xb3, 0xe8, 0x39, 0x0c, 0xd4, 0x9b, 0x77,
+	0x19, 0xdb, 0xbb, 0x3d, 0x5d, 0x6a, 0x5f, 0x9d, 0x31, 0xdb, 0xcf, 0x3e, 0xc1, 0xe4, 0xcc, 0x8e,
+	0xa6, 0xd0, 0x7e, 0x60, 0xa6, 0x59, 0x0e, 0x56, 0x21, 0xba, 0x82, 0xee, 0x4f, 0x72, 0xc8, 0x99,
+	0x6b, 0x2f, 0xac, 0xe5, 0x00, 0x9b, 0xc3, 0x8d, 0xfd, 0xc1, 0x9a, 0xdd, 0xc0, 0xa8, 0xfe, 0x92,
+	0xff, 0x79, 0x9d, 0xba, 0xf7, 0x3b, 0x5c, 0x5d, 0x2a, 0xff, 0x02, 0xe3, 0x6d, 0x9d, 0x31, 0xbc,
+	0x7e, 0x56, 0xf5, 0xa2, 0x32, 0x7e, 0x53, 0x97, 0x35, 0xb4, 0xf7, 0xc7, 0x86, 0x71, 0xf3, 0x16,
+	0xbd, 0x82, 0x91, 0x90, 0x59, 0x9c, 0x44, 0x3b, 0x83, 0xd2, 0xf8, 0xa0, 0x85, 0x87, 0x26, 0x6b,
+	0x44, 0x2f, 0xc0, 0x89, 0x13, 0xb9, 0x7b, 0xfc, 0xb3, 0x76, 0xd0, 0xc2, 0x83, 0x38, 0x91, 0x27,
+	0x06, 0xe5, 0x79, 0x78, 0x60, 0xa5, 0x42, 0x7d, 0x76, 0x4b, 0x31, 0x4c, 0xd6, 0x88, 0xe6, 0x00,
+	0x21, 0xe7, 0x87, 0x52, 0xd2, 0x51, 0x1d, 0x0b, 0x5a, 0xd8, 0x51, 0x39, 0x23, 0x78, 0x09, 0xc3,
+	0xb0, 0x90, 0x4c, 0x94, 0x8a, 0xae, 0x1a, 0xca, 0xa0, 0x85, 0x41, 0x27, 0x8d, 0x64, 0x03, 0x93,
+	0xd3, 0x58, 0x97, 0xb2, 0x9e, 0x7e, 0xfa, 0xcc, 0x37, 0xe3, 0xef, 0x57, 0xe3, 0xef, 0x6f, 0x2b,
+	0x5d, 0xd0, 0xc2, 0xe3, 0x93, 0x49, 0x63, 0xd6, 0xfd, 0xb2, 0x6f, 0x9e, 0x6a, 0x35, 0x89, 0x22,
+	0x46, 0xcf, 0x16, 0x63, 0x0a, 0xed, 0x2d, 0x89, 0x74, 0x2f, 0x3a, 0x58, 0x85, 0x68, 0x05, 0xfd,
+	0x72, 0xed, 0xce, 0x9b, 0xdd, 0x70, 0xe2, 0x4a, 0xe5, 0xcd, 0xc1, 0x59, 0x73, 0x5a, 0x7c, 0xbe,
+	0xcf, 0x93, 0x07, 0xb5, 0x68, 0x94, 0x48, 0x52, 0x2d, 0x9a, 0x8a, 0xbd, 0x15, 0xa0, 0xbb, 0xf4,
+	0xc0, 0x09, 0x5d, 0xeb, 0x91, 0x13, 0x29, 0x4f, 0x04, 0x6b, 0x8c, 0xa4, 0xd5, 0x18, 0x49, 0xef,
+	0x1d, 0x3c, 0xfd, 0xc2, 0x7f, 0x25, 0x8f, 0x96, 0x1f, 0x39, 0x13, 0xf2, 0x1f, 0x8e, 0xb0, 0xa7,
+	0x4b, 0x7c, 0xff, 0x77, 0x00, 0x47, 0xe3, 0x56, 0x42, 0x32, 0x04, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ package proto
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -23,8 +24,121 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type LogLevel int32
+
+const (
+	LogLevel_INFO  LogLevel = 0
+	LogLevel_DEBUG LogLevel = 1
+	LogLevel_WARN  LogLevel = 2
+	LogLevel_ERROR LogLevel = 3
+	LogLevel_FATAL LogLevel = 4
+	LogLevel_PANIC LogLevel = 5
+)
+
+var LogLevel_name = map[int32]string{
+	0: "INFO",
+	1: "DEBUG",
+	2: "WARN",
+	3: "ERROR",
+	4: "FATAL",
+	5: "PANIC",
+}
+var LogLevel_value = map[string]int32{
+	"INFO":  0,
+	"DEBUG": 1,
+	"WARN":  2,
+	"ERROR": 3,
+	"FATAL": 4,
+	"PANIC": 5,
+}
+
+func (x LogLevel) String() string {
+	return proto.EnumName(LogLevel_name, int32(x))
+}
+func (LogLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{0}
+}
+
+type MetricKind int32
+
+const (
+	MetricKind_COUNTER   MetricKind = 0
+	MetricKind_GAUGE     MetricKind = 1
+	MetricKind_HISTOGRAM MetricKind = 2
+)
+
+var MetricKind_name = map[int32]string{
+	0: "COUNTER",
+	1: "GAUGE",
+	2: "HISTOGRAM",
+}
+var MetricKind_value = map[string]int32{
+	"COUNTER":   0,
+	"GAUGE":     1,
+	"HISTOGRAM": 2,
+}
+
+func (x MetricKind) String() string {
+	return proto.EnumName(MetricKind_name, int32(x))
+}
+func (MetricKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{1}
+}
+
+type LogField struct {
+	Key                  string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                *AttributeValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *LogField) Reset()         { *m = LogField{} }
+func (m *LogField) String() string { return proto.CompactTextString(m) }
+func (*LogField) ProtoMessage()    {}
+func (*LogField) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{0}
+}
+func (m *LogField) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogField.Unmarshal(m, b)
+}
+func (m *LogField) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogField.Marshal(b, m, deterministic)
+}
+func (dst *LogField) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogField.Merge(dst, src)
+}
+func (m *LogField) XXX_Size() int {
+	return xxx_messageInfo_LogField.Size(m)
+}
+func (m *LogField) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogField.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogField proto.InternalMessageInfo
+
+func (m *LogField) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LogField) GetValue() *AttributeValue {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 type LogRequest struct {
-	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// only used by Log; the other log RPCs imply the level
+	Level  LogLevel             `protobuf:"varint,2,opt,name=level,proto3,enum=proto.LogLevel" json:"level,omitempty"`
+	Fields []*LogField          `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	Time   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// the source location that logged the message, as file:line
+	Caller               string   `protobuf:"bytes,5,opt,name=caller,proto3" json:"caller,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -34,7 +148,7 @@ func (m *LogRequest) Reset()         { *m = LogRequest{} }
 func (m *LogRequest) String() string { return proto.CompactTextString(m) }
 func (*LogRequest) ProtoMessage()    {}
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_stub_57997c212aadf793, []int{0}
+	return fileDescriptor_stub_a3d68cb218f50373, []int{1}
 }
 func (m *LogRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogRequest.Unmarshal(m, b)
@@ -61,39 +175,899 @@ func (m *LogRequest) GetMessage() string {
 	return ""
 }
 
-type LogResponse struct {
+func (m *LogRequest) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
+	}
+	return LogLevel_INFO
+}
+
+func (m *LogRequest) GetFields() []*LogField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *LogRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *LogRequest) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+type LogResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogResponse) Reset()         { *m = LogResponse{} }
+func (m *LogResponse) String() string { return proto.CompactTextString(m) }
+func (*LogResponse) ProtoMessage()    {}
+func (*LogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{2}
+}
+func (m *LogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogResponse.Unmarshal(m, b)
+}
+func (m *LogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogResponse.Marshal(b, m, deterministic)
+}
+func (dst *LogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogResponse.Merge(dst, src)
+}
+func (m *LogResponse) XXX_Size() int {
+	return xxx_messageInfo_LogResponse.Size(m)
+}
+func (m *LogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogResponse proto.InternalMessageInfo
+
+type LogBatch struct {
+	Records []*LogRequest `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// the number of records that the plugin dropped since the previous batch
+	Dropped              uint64   `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogBatch) Reset()         { *m = LogBatch{} }
+func (m *LogBatch) String() string { return proto.CompactTextString(m) }
+func (*LogBatch) ProtoMessage()    {}
+func (*LogBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{3}
+}
+func (m *LogBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogBatch.Unmarshal(m, b)
+}
+func (m *LogBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogBatch.Marshal(b, m, deterministic)
+}
+func (dst *LogBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogBatch.Merge(dst, src)
+}
+func (m *LogBatch) XXX_Size() int {
+	return xxx_messageInfo_LogBatch.Size(m)
+}
+func (m *LogBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogBatch proto.InternalMessageInfo
+
+func (m *LogBatch) GetRecords() []*LogRequest {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *LogBatch) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+type WatchLogLevelRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchLogLevelRequest) Reset()         { *m = WatchLogLevelRequest{} }
+func (m *WatchLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchLogLevelRequest) ProtoMessage()    {}
+func (*WatchLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{4}
+}
+func (m *WatchLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchLogLevelRequest.Unmarshal(m, b)
+}
+func (m *WatchLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (dst *WatchLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchLogLevelRequest.Merge(dst, src)
+}
+func (m *WatchLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchLogLevelRequest.Size(m)
+}
+func (m *WatchLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchLogLevelRequest proto.InternalMessageInfo
+
+type LogLevelUpdate struct {
+	// the lowest level that the host logs; the plugin drops records below it
+	Level                LogLevel `protobuf:"varint,1,opt,name=level,proto3,enum=proto.LogLevel" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogLevelUpdate) Reset()         { *m = LogLevelUpdate{} }
+func (m *LogLevelUpdate) String() string { return proto.CompactTextString(m) }
+func (*LogLevelUpdate) ProtoMessage()    {}
+func (*LogLevelUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{5}
+}
+func (m *LogLevelUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogLevelUpdate.Unmarshal(m, b)
+}
+func (m *LogLevelUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogLevelUpdate.Marshal(b, m, deterministic)
+}
+func (dst *LogLevelUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevelUpdate.Merge(dst, src)
+}
+func (m *LogLevelUpdate) XXX_Size() int {
+	return xxx_messageInfo_LogLevelUpdate.Size(m)
+}
+func (m *LogLevelUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevelUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogLevelUpdate proto.InternalMessageInfo
+
+func (m *LogLevelUpdate) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
+	}
+	return LogLevel_INFO
+}
+
+type MetricSample struct {
+	Kind   MetricKind        `protobuf:"varint,1,opt,name=kind,proto3,enum=proto.MetricKind" json:"kind,omitempty"`
+	Name   string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the sum of the increments of a counter, the last value of a gauge, or the sum of the observations of a histogram
+	Value float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// the number of observations of a histogram up to each of the DefaultBuckets of the plugin
+	Buckets []uint64 `protobuf:"varint,6,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+	// the number of observations of a histogram
+	Count                uint64   `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetricSample) Reset()         { *m = MetricSample{} }
+func (m *MetricSample) String() string { return proto.CompactTextString(m) }
+func (*MetricSample) ProtoMessage()    {}
+func (*MetricSample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{6}
+}
+func (m *MetricSample) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricSample.Unmarshal(m, b)
+}
+func (m *MetricSample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricSample.Marshal(b, m, deterministic)
+}
+func (dst *MetricSample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricSample.Merge(dst, src)
+}
+func (m *MetricSample) XXX_Size() int {
+	return xxx_messageInfo_MetricSample.Size(m)
+}
+func (m *MetricSample) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricSample.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricSample proto.InternalMessageInfo
+
+func (m *MetricSample) GetKind() MetricKind {
+	if m != nil {
+		return m.Kind
+	}
+	return MetricKind_COUNTER
+}
+
+func (m *MetricSample) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MetricSample) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *MetricSample) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *MetricSample) GetBuckets() []uint64 {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *MetricSample) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type RecordMetricsRequest struct {
+	Samples []*MetricSample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+	// the number of samples that the plugin failed to send since the last request
+	Dropped              uint64   `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordMetricsRequest) Reset()         { *m = RecordMetricsRequest{} }
+func (m *RecordMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*RecordMetricsRequest) ProtoMessage()    {}
+func (*RecordMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{7}
+}
+func (m *RecordMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordMetricsRequest.Unmarshal(m, b)
+}
+func (m *RecordMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordMetricsRequest.Marshal(b, m, deterministic)
+}
+func (dst *RecordMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordMetricsRequest.Merge(dst, src)
+}
+func (m *RecordMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_RecordMetricsRequest.Size(m)
+}
+func (m *RecordMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordMetricsRequest proto.InternalMessageInfo
+
+func (m *RecordMetricsRequest) GetSamples() []*MetricSample {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
+func (m *RecordMetricsRequest) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+type RecordMetricsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordMetricsResponse) Reset()         { *m = RecordMetricsResponse{} }
+func (m *RecordMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*RecordMetricsResponse) ProtoMessage()    {}
+func (*RecordMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{8}
+}
+func (m *RecordMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordMetricsResponse.Unmarshal(m, b)
+}
+func (m *RecordMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordMetricsResponse.Marshal(b, m, deterministic)
+}
+func (dst *RecordMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordMetricsResponse.Merge(dst, src)
+}
+func (m *RecordMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_RecordMetricsResponse.Size(m)
+}
+func (m *RecordMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordMetricsResponse proto.InternalMessageInfo
+
+type KVGetRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVGetRequest) Reset()         { *m = KVGetRequest{} }
+func (m *KVGetRequest) String() string { return proto.CompactTextString(m) }
+func (*KVGetRequest) ProtoMessage()    {}
+func (*KVGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{9}
+}
+func (m *KVGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVGetRequest.Unmarshal(m, b)
+}
+func (m *KVGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVGetRequest.Marshal(b, m, deterministic)
+}
+func (dst *KVGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVGetRequest.Merge(dst, src)
+}
+func (m *KVGetRequest) XXX_Size() int {
+	return xxx_messageInfo_KVGetRequest.Size(m)
+}
+func (m *KVGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVGetRequest proto.InternalMessageInfo
+
+func (m *KVGetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type KVGetResponse struct {
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Found                bool     `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVGetResponse) Reset()         { *m = KVGetResponse{} }
+func (m *KVGetResponse) String() string { return proto.CompactTextString(m) }
+func (*KVGetResponse) ProtoMessage()    {}
+func (*KVGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{10}
+}
+func (m *KVGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVGetResponse.Unmarshal(m, b)
+}
+func (m *KVGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVGetResponse.Marshal(b, m, deterministic)
+}
+func (dst *KVGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVGetResponse.Merge(dst, src)
+}
+func (m *KVGetResponse) XXX_Size() int {
+	return xxx_messageInfo_KVGetResponse.Size(m)
+}
+func (m *KVGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVGetResponse proto.InternalMessageInfo
+
+func (m *KVGetResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *KVGetResponse) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
+
+type KVPutRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVPutRequest) Reset()         { *m = KVPutRequest{} }
+func (m *KVPutRequest) String() string { return proto.CompactTextString(m) }
+func (*KVPutRequest) ProtoMessage()    {}
+func (*KVPutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{11}
+}
+func (m *KVPutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVPutRequest.Unmarshal(m, b)
+}
+func (m *KVPutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVPutRequest.Marshal(b, m, deterministic)
+}
+func (dst *KVPutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVPutRequest.Merge(dst, src)
+}
+func (m *KVPutRequest) XXX_Size() int {
+	return xxx_messageInfo_KVPutRequest.Size(m)
+}
+func (m *KVPutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVPutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVPutRequest proto.InternalMessageInfo
+
+func (m *KVPutRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KVPutRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type KVPutResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVPutResponse) Reset()         { *m = KVPutResponse{} }
+func (m *KVPutResponse) String() string { return proto.CompactTextString(m) }
+func (*KVPutResponse) ProtoMessage()    {}
+func (*KVPutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{12}
+}
+func (m *KVPutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVPutResponse.Unmarshal(m, b)
+}
+func (m *KVPutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVPutResponse.Marshal(b, m, deterministic)
+}
+func (dst *KVPutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVPutResponse.Merge(dst, src)
+}
+func (m *KVPutResponse) XXX_Size() int {
+	return xxx_messageInfo_KVPutResponse.Size(m)
+}
+func (m *KVPutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVPutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVPutResponse proto.InternalMessageInfo
+
+type KVDeleteRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVDeleteRequest) Reset()         { *m = KVDeleteRequest{} }
+func (m *KVDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*KVDeleteRequest) ProtoMessage()    {}
+func (*KVDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{13}
+}
+func (m *KVDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVDeleteRequest.Unmarshal(m, b)
+}
+func (m *KVDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVDeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *KVDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVDeleteRequest.Merge(dst, src)
+}
+func (m *KVDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_KVDeleteRequest.Size(m)
+}
+func (m *KVDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVDeleteRequest proto.InternalMessageInfo
+
+func (m *KVDeleteRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type KVDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVDeleteResponse) Reset()         { *m = KVDeleteResponse{} }
+func (m *KVDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*KVDeleteResponse) ProtoMessage()    {}
+func (*KVDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{14}
+}
+func (m *KVDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVDeleteResponse.Unmarshal(m, b)
+}
+func (m *KVDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVDeleteResponse.Marshal(b, m, deterministic)
+}
+func (dst *KVDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVDeleteResponse.Merge(dst, src)
+}
+func (m *KVDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_KVDeleteResponse.Size(m)
+}
+func (m *KVDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVDeleteResponse proto.InternalMessageInfo
+
+type KVListRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVListRequest) Reset()         { *m = KVListRequest{} }
+func (m *KVListRequest) String() string { return proto.CompactTextString(m) }
+func (*KVListRequest) ProtoMessage()    {}
+func (*KVListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{15}
+}
+func (m *KVListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVListRequest.Unmarshal(m, b)
+}
+func (m *KVListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVListRequest.Marshal(b, m, deterministic)
+}
+func (dst *KVListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVListRequest.Merge(dst, src)
+}
+func (m *KVListRequest) XXX_Size() int {
+	return xxx_messageInfo_KVListRequest.Size(m)
+}
+func (m *KVListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVListRequest proto.InternalMessageInfo
+
+func (m *KVListRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type KVPair struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVPair) Reset()         { *m = KVPair{} }
+func (m *KVPair) String() string { return proto.CompactTextString(m) }
+func (*KVPair) ProtoMessage()    {}
+func (*KVPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{16}
+}
+func (m *KVPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVPair.Unmarshal(m, b)
+}
+func (m *KVPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVPair.Marshal(b, m, deterministic)
+}
+func (dst *KVPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVPair.Merge(dst, src)
+}
+func (m *KVPair) XXX_Size() int {
+	return xxx_messageInfo_KVPair.Size(m)
+}
+func (m *KVPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVPair proto.InternalMessageInfo
+
+func (m *KVPair) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KVPair) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type KVListResponse struct {
+	Pairs                []*KVPair `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *KVListResponse) Reset()         { *m = KVListResponse{} }
+func (m *KVListResponse) String() string { return proto.CompactTextString(m) }
+func (*KVListResponse) ProtoMessage()    {}
+func (*KVListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{17}
+}
+func (m *KVListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVListResponse.Unmarshal(m, b)
+}
+func (m *KVListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVListResponse.Marshal(b, m, deterministic)
+}
+func (dst *KVListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVListResponse.Merge(dst, src)
+}
+func (m *KVListResponse) XXX_Size() int {
+	return xxx_messageInfo_KVListResponse.Size(m)
+}
+func (m *KVListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVListResponse proto.InternalMessageInfo
+
+func (m *KVListResponse) GetPairs() []*KVPair {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+type KVCompareAndSwapRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Old []byte `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	// distinguishes an empty `old` value from a key that must not exist yet
+	OldExists            bool     `protobuf:"varint,3,opt,name=old_exists,json=oldExists,proto3" json:"old_exists,omitempty"`
+	Value                []byte   `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVCompareAndSwapRequest) Reset()         { *m = KVCompareAndSwapRequest{} }
+func (m *KVCompareAndSwapRequest) String() string { return proto.CompactTextString(m) }
+func (*KVCompareAndSwapRequest) ProtoMessage()    {}
+func (*KVCompareAndSwapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{18}
+}
+func (m *KVCompareAndSwapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVCompareAndSwapRequest.Unmarshal(m, b)
+}
+func (m *KVCompareAndSwapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVCompareAndSwapRequest.Marshal(b, m, deterministic)
+}
+func (dst *KVCompareAndSwapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVCompareAndSwapRequest.Merge(dst, src)
+}
+func (m *KVCompareAndSwapRequest) XXX_Size() int {
+	return xxx_messageInfo_KVCompareAndSwapRequest.Size(m)
+}
+func (m *KVCompareAndSwapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVCompareAndSwapRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVCompareAndSwapRequest proto.InternalMessageInfo
+
+func (m *KVCompareAndSwapRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KVCompareAndSwapRequest) GetOld() []byte {
+	if m != nil {
+		return m.Old
+	}
+	return nil
+}
+
+func (m *KVCompareAndSwapRequest) GetOldExists() bool {
+	if m != nil {
+		return m.OldExists
+	}
+	return false
+}
+
+func (m *KVCompareAndSwapRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type KVCompareAndSwapResponse struct {
+	Swapped              bool     `protobuf:"varint,1,opt,name=swapped,proto3" json:"swapped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVCompareAndSwapResponse) Reset()         { *m = KVCompareAndSwapResponse{} }
+func (m *KVCompareAndSwapResponse) String() string { return proto.CompactTextString(m) }
+func (*KVCompareAndSwapResponse) ProtoMessage()    {}
+func (*KVCompareAndSwapResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{19}
+}
+func (m *KVCompareAndSwapResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVCompareAndSwapResponse.Unmarshal(m, b)
+}
+func (m *KVCompareAndSwapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVCompareAndSwapResponse.Marshal(b, m, deterministic)
+}
+func (dst *KVCompareAndSwapResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVCompareAndSwapResponse.Merge(dst, src)
+}
+func (m *KVCompareAndSwapResponse) XXX_Size() int {
+	return xxx_messageInfo_KVCompareAndSwapResponse.Size(m)
+}
+func (m *KVCompareAndSwapResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVCompareAndSwapResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVCompareAndSwapResponse proto.InternalMessageInfo
+
+func (m *KVCompareAndSwapResponse) GetSwapped() bool {
+	if m != nil {
+		return m.Swapped
+	}
+	return false
+}
+
+type SecretRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SecretRequest) Reset()         { *m = SecretRequest{} }
+func (m *SecretRequest) String() string { return proto.CompactTextString(m) }
+func (*SecretRequest) ProtoMessage()    {}
+func (*SecretRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{20}
+}
+func (m *SecretRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecretRequest.Unmarshal(m, b)
+}
+func (m *SecretRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecretRequest.Marshal(b, m, deterministic)
+}
+func (dst *SecretRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretRequest.Merge(dst, src)
+}
+func (m *SecretRequest) XXX_Size() int {
+	return xxx_messageInfo_SecretRequest.Size(m)
+}
+func (m *SecretRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretRequest proto.InternalMessageInfo
+
+func (m *SecretRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SecretResponse struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Found                bool     `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LogResponse) Reset()         { *m = LogResponse{} }
-func (m *LogResponse) String() string { return proto.CompactTextString(m) }
-func (*LogResponse) ProtoMessage()    {}
-func (*LogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_stub_57997c212aadf793, []int{1}
+func (m *SecretResponse) Reset()         { *m = SecretResponse{} }
+func (m *SecretResponse) String() string { return proto.CompactTextString(m) }
+func (*SecretResponse) ProtoMessage()    {}
+func (*SecretResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_stub_a3d68cb218f50373, []int{21}
 }
-func (m *LogResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LogResponse.Unmarshal(m, b)
+func (m *SecretResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecretResponse.Unmarshal(m, b)
 }
-func (m *LogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LogResponse.Marshal(b, m, deterministic)
+func (m *SecretResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecretResponse.Marshal(b, m, deterministic)
 }
-func (dst *LogResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogResponse.Merge(dst, src)
+func (dst *SecretResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretResponse.Merge(dst, src)
 }
-func (m *LogResponse) XXX_Size() int {
-	return xxx_messageInfo_LogResponse.Size(m)
+func (m *SecretResponse) XXX_Size() int {
+	return xxx_messageInfo_SecretResponse.Size(m)
 }
-func (m *LogResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogResponse.DiscardUnknown(m)
+func (m *SecretResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogResponse proto.InternalMessageInfo
+var xxx_messageInfo_SecretResponse proto.InternalMessageInfo
+
+func (m *SecretResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *SecretResponse) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
 
 func init() {
+	proto.RegisterType((*LogField)(nil), "proto.LogField")
 	proto.RegisterType((*LogRequest)(nil), "proto.LogRequest")
 	proto.RegisterType((*LogResponse)(nil), "proto.LogResponse")
+	proto.RegisterType((*LogBatch)(nil), "proto.LogBatch")
+	proto.RegisterType((*WatchLogLevelRequest)(nil), "proto.WatchLogLevelRequest")
+	proto.RegisterType((*LogLevelUpdate)(nil), "proto.LogLevelUpdate")
+	proto.RegisterType((*MetricSample)(nil), "proto.MetricSample")
+	proto.RegisterMapType((map[string]string)(nil), "proto.MetricSample.LabelsEntry")
+	proto.RegisterType((*RecordMetricsRequest)(nil), "proto.RecordMetricsRequest")
+	proto.RegisterType((*RecordMetricsResponse)(nil), "proto.RecordMetricsResponse")
+	proto.RegisterType((*KVGetRequest)(nil), "proto.KVGetRequest")
+	proto.RegisterType((*KVGetResponse)(nil), "proto.KVGetResponse")
+	proto.RegisterType((*KVPutRequest)(nil), "proto.KVPutRequest")
+	proto.RegisterType((*KVPutResponse)(nil), "proto.KVPutResponse")
+	proto.RegisterType((*KVDeleteRequest)(nil), "proto.KVDeleteRequest")
+	proto.RegisterType((*KVDeleteResponse)(nil), "proto.KVDeleteResponse")
+	proto.RegisterType((*KVListRequest)(nil), "proto.KVListRequest")
+	proto.RegisterType((*KVPair)(nil), "proto.KVPair")
+	proto.RegisterType((*KVListResponse)(nil), "proto.KVListResponse")
+	proto.RegisterType((*KVCompareAndSwapRequest)(nil), "proto.KVCompareAndSwapRequest")
+	proto.RegisterType((*KVCompareAndSwapResponse)(nil), "proto.KVCompareAndSwapResponse")
+	proto.RegisterType((*SecretRequest)(nil), "proto.SecretRequest")
+	proto.RegisterType((*SecretResponse)(nil), "proto.SecretResponse")
+	proto.RegisterEnum("proto.LogLevel", LogLevel_name, LogLevel_value)
+	proto.RegisterEnum("proto.MetricKind", MetricKind_name, MetricKind_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -108,12 +1082,22 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type StubHelperClient interface {
+	Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
+	LogStream(ctx context.Context, opts ...grpc.CallOption) (StubHelper_LogStreamClient, error)
+	WatchLogLevel(ctx context.Context, in *WatchLogLevelRequest, opts ...grpc.CallOption) (StubHelper_WatchLogLevelClient, error)
 	Printf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	Fatalf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	Panicf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	Debugf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	Warnf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	Errorf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
+	RecordMetrics(ctx context.Context, in *RecordMetricsRequest, opts ...grpc.CallOption) (*RecordMetricsResponse, error)
+	KVGet(ctx context.Context, in *KVGetRequest, opts ...grpc.CallOption) (*KVGetResponse, error)
+	KVPut(ctx context.Context, in *KVPutRequest, opts ...grpc.CallOption) (*KVPutResponse, error)
+	KVDelete(ctx context.Context, in *KVDeleteRequest, opts ...grpc.CallOption) (*KVDeleteResponse, error)
+	KVList(ctx context.Context, in *KVListRequest, opts ...grpc.CallOption) (*KVListResponse, error)
+	KVCompareAndSwap(ctx context.Context, in *KVCompareAndSwapRequest, opts ...grpc.CallOption) (*KVCompareAndSwapResponse, error)
+	Secret(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*SecretResponse, error)
 }
 
 type stubHelperClient struct {
@@ -124,6 +1108,81 @@ func NewStubHelperClient(cc *grpc.ClientConn) StubHelperClient {
 	return &stubHelperClient{cc}
 }
 
+func (c *stubHelperClient) Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error) {
+	out := new(LogResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/Log", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) LogStream(ctx context.Context, opts ...grpc.CallOption) (StubHelper_LogStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StubHelper_serviceDesc.Streams[0], "/proto.StubHelper/LogStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stubHelperLogStreamClient{stream}
+	return x, nil
+}
+
+type StubHelper_LogStreamClient interface {
+	Send(*LogBatch) error
+	CloseAndRecv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type stubHelperLogStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *stubHelperLogStreamClient) Send(m *LogBatch) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *stubHelperLogStreamClient) CloseAndRecv() (*LogResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stubHelperClient) WatchLogLevel(ctx context.Context, in *WatchLogLevelRequest, opts ...grpc.CallOption) (StubHelper_WatchLogLevelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StubHelper_serviceDesc.Streams[1], "/proto.StubHelper/WatchLogLevel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stubHelperWatchLogLevelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StubHelper_WatchLogLevelClient interface {
+	Recv() (*LogLevelUpdate, error)
+	grpc.ClientStream
+}
+
+type stubHelperWatchLogLevelClient struct {
+	grpc.ClientStream
+}
+
+func (x *stubHelperWatchLogLevelClient) Recv() (*LogLevelUpdate, error) {
+	m := new(LogLevelUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *stubHelperClient) Printf(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error) {
 	out := new(LogResponse)
 	err := c.cc.Invoke(ctx, "/proto.StubHelper/Printf", in, out, opts...)
@@ -178,20 +1237,158 @@ func (c *stubHelperClient) Errorf(ctx context.Context, in *LogRequest, opts ...g
 	return out, nil
 }
 
+func (c *stubHelperClient) RecordMetrics(ctx context.Context, in *RecordMetricsRequest, opts ...grpc.CallOption) (*RecordMetricsResponse, error) {
+	out := new(RecordMetricsResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/RecordMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) KVGet(ctx context.Context, in *KVGetRequest, opts ...grpc.CallOption) (*KVGetResponse, error) {
+	out := new(KVGetResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/KVGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) KVPut(ctx context.Context, in *KVPutRequest, opts ...grpc.CallOption) (*KVPutResponse, error) {
+	out := new(KVPutResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/KVPut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) KVDelete(ctx context.Context, in *KVDeleteRequest, opts ...grpc.CallOption) (*KVDeleteResponse, error) {
+	out := new(KVDeleteResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/KVDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) KVList(ctx context.Context, in *KVListRequest, opts ...grpc.CallOption) (*KVListResponse, error) {
+	out := new(KVListResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/KVList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) KVCompareAndSwap(ctx context.Context, in *KVCompareAndSwapRequest, opts ...grpc.CallOption) (*KVCompareAndSwapResponse, error) {
+	out := new(KVCompareAndSwapResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/KVCompareAndSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stubHelperClient) Secret(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*SecretResponse, error) {
+	out := new(SecretResponse)
+	err := c.cc.Invoke(ctx, "/proto.StubHelper/Secret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StubHelperServer is the server API for StubHelper service.
 type StubHelperServer interface {
+	Log(context.Context, *LogRequest) (*LogResponse, error)
+	LogStream(StubHelper_LogStreamServer) error
+	WatchLogLevel(*WatchLogLevelRequest, StubHelper_WatchLogLevelServer) error
 	Printf(context.Context, *LogRequest) (*LogResponse, error)
 	Fatalf(context.Context, *LogRequest) (*LogResponse, error)
 	Panicf(context.Context, *LogRequest) (*LogResponse, error)
 	Debugf(context.Context, *LogRequest) (*LogResponse, error)
 	Warnf(context.Context, *LogRequest) (*LogResponse, error)
 	Errorf(context.Context, *LogRequest) (*LogResponse, error)
+	RecordMetrics(context.Context, *RecordMetricsRequest) (*RecordMetricsResponse, error)
+	KVGet(context.Context, *KVGetRequest) (*KVGetResponse, error)
+	KVPut(context.Context, *KVPutRequest) (*KVPutResponse, error)
+	KVDelete(context.Context, *KVDeleteRequest) (*KVDeleteResponse, error)
+	KVList(context.Context, *KVListRequest) (*KVListResponse, error)
+	KVCompareAndSwap(context.Context, *KVCompareAndSwapRequest) (*KVCompareAndSwapResponse, error)
+	Secret(context.Context, *SecretRequest) (*SecretResponse, error)
 }
 
 func RegisterStubHelperServer(s *grpc.Server, srv StubHelperServer) {
 	s.RegisterService(&_StubHelper_serviceDesc, srv)
 }
 
+func _StubHelper_Log_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).Log(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/Log",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).Log(ctx, req.(*LogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_LogStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StubHelperServer).LogStream(&stubHelperLogStreamServer{stream})
+}
+
+type StubHelper_LogStreamServer interface {
+	SendAndClose(*LogResponse) error
+	Recv() (*LogBatch, error)
+	grpc.ServerStream
+}
+
+type stubHelperLogStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *stubHelperLogStreamServer) SendAndClose(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *stubHelperLogStreamServer) Recv() (*LogBatch, error) {
+	m := new(LogBatch)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StubHelper_WatchLogLevel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLogLevelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StubHelperServer).WatchLogLevel(m, &stubHelperWatchLogLevelServer{stream})
+}
+
+type StubHelper_WatchLogLevelServer interface {
+	Send(*LogLevelUpdate) error
+	grpc.ServerStream
+}
+
+type stubHelperWatchLogLevelServer struct {
+	grpc.ServerStream
+}
+
+func (x *stubHelperWatchLogLevelServer) Send(m *LogLevelUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _StubHelper_Printf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LogRequest)
 	if err := dec(in); err != nil {
@@ -300,10 +1497,140 @@ func _StubHelper_Errorf_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StubHelper_RecordMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).RecordMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/RecordMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).RecordMetrics(ctx, req.(*RecordMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_KVGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KVGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).KVGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/KVGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).KVGet(ctx, req.(*KVGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_KVPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KVPutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).KVPut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/KVPut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).KVPut(ctx, req.(*KVPutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_KVDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KVDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).KVDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/KVDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).KVDelete(ctx, req.(*KVDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_KVList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KVListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).KVList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/KVList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).KVList(ctx, req.(*KVListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_KVCompareAndSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KVCompareAndSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).KVCompareAndSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/KVCompareAndSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).KVCompareAndSwap(ctx, req.(*KVCompareAndSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StubHelper_Secret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StubHelperServer).Secret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.StubHelper/Secret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StubHelperServer).Secret(ctx, req.(*SecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _StubHelper_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.StubHelper",
 	HandlerType: (*StubHelperServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Log",
+			Handler:    _StubHelper_Log_Handler,
+		},
 		{
 			MethodName: "Printf",
 			Handler:    _StubHelper_Printf_Handler,
@@ -328,24 +1655,116 @@ var _StubHelper_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Errorf",
 			Handler:    _StubHelper_Errorf_Handler,
 		},
+		{
+			MethodName: "RecordMetrics",
+			Handler:    _StubHelper_RecordMetrics_Handler,
+		},
+		{
+			MethodName: "KVGet",
+			Handler:    _StubHelper_KVGet_Handler,
+		},
+		{
+			MethodName: "KVPut",
+			Handler:    _StubHelper_KVPut_Handler,
+		},
+		{
+			MethodName: "KVDelete",
+			Handler:    _StubHelper_KVDelete_Handler,
+		},
+		{
+			MethodName: "KVList",
+			Handler:    _StubHelper_KVList_Handler,
+		},
+		{
+			MethodName: "KVCompareAndSwap",
+			Handler:    _StubHelper_KVCompareAndSwap_Handler,
+		},
+		{
+			MethodName: "Secret",
+			Handler:    _StubHelper_Secret_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "LogStream",
+			Handler:       _StubHelper_LogStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchLogLevel",
+			Handler:       _StubHelper_WatchLogLevel_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "stub.proto",
 }
 
-func init() { proto.RegisterFile("stub.proto", fileDescriptor_stub_57997c212aadf793) }
-
-var fileDescriptor_stub_57997c212aadf793 = []byte{
-	// 170 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0x2e, 0x29, 0x4d,
-	0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x4a, 0x6a, 0x5c, 0x5c, 0x3e, 0xf9,
-	0xe9, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x42, 0x12, 0x5c, 0xec, 0xb9, 0xa9, 0xc5, 0xc5,
-	0x89, 0xe9, 0xa9, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x30, 0xae, 0x12, 0x2f, 0x17, 0x37,
-	0x58, 0x5d, 0x71, 0x41, 0x7e, 0x5e, 0x71, 0xaa, 0xd1, 0x46, 0x26, 0x2e, 0xae, 0xe0, 0x92, 0xd2,
-	0x24, 0x8f, 0xd4, 0x9c, 0x82, 0xd4, 0x22, 0x21, 0x7d, 0x2e, 0xb6, 0x80, 0xa2, 0xcc, 0xbc, 0x92,
-	0x34, 0x21, 0x41, 0x88, 0xf1, 0x7a, 0x08, 0x43, 0xa5, 0x84, 0x90, 0x85, 0x20, 0xfa, 0x41, 0x1a,
-	0xdc, 0x12, 0x4b, 0x12, 0x73, 0x48, 0xd1, 0x10, 0x90, 0x98, 0x97, 0x99, 0x4c, 0x8a, 0x06, 0x97,
-	0xd4, 0xa4, 0xd2, 0x74, 0xa2, 0x35, 0xe8, 0x71, 0xb1, 0x86, 0x27, 0x16, 0xe5, 0x91, 0x62, 0x81,
-	0x6b, 0x51, 0x51, 0x7e, 0x11, 0xb1, 0x1a, 0x92, 0xd8, 0xc0, 0x42, 0xc6, 0x80, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xb8, 0x7d, 0x05, 0x23, 0x86, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("stub.proto", fileDescriptor_stub_a3d68cb218f50373) }
+
+var fileDescriptor_stub_a3d68cb218f50373 = []byte{
+	// 1011 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5b, 0x53, 0xdb, 0x46,
+	0x14, 0xae, 0xb0, 0x25, 0xe3, 0x03, 0x06, 0x75, 0x63, 0x40, 0xa3, 0x5e, 0x60, 0xc4, 0x74, 0xc2,
+	0x24, 0xad, 0xc9, 0x90, 0xa6, 0x69, 0x9b, 0xbe, 0x38, 0x60, 0x08, 0xe0, 0x80, 0xbb, 0xe6, 0xf2,
+	0x98, 0x91, 0xad, 0xb5, 0xab, 0x41, 0x96, 0xd4, 0xd5, 0x2a, 0x97, 0x9f, 0xd0, 0x97, 0xfe, 0xa4,
+	0xfe, 0xb6, 0xce, 0xde, 0x6c, 0xd9, 0x18, 0x06, 0x9e, 0xb4, 0xe7, 0xec, 0xf7, 0x9d, 0xfd, 0xf6,
+	0xec, 0xd1, 0x07, 0x90, 0xb1, 0xbc, 0xd7, 0x48, 0x69, 0xc2, 0x12, 0x64, 0x8a, 0x8f, 0xbb, 0x39,
+	0x4c, 0x92, 0x61, 0x44, 0x76, 0x45, 0xd4, 0xcb, 0x07, 0xbb, 0x2c, 0x1c, 0x91, 0x8c, 0xf9, 0xa3,
+	0x54, 0xe2, 0xdc, 0xda, 0x88, 0x64, 0x99, 0x3f, 0x24, 0x32, 0xf4, 0x8e, 0x61, 0xb1, 0x9d, 0x0c,
+	0x0f, 0x43, 0x12, 0x05, 0xc8, 0x86, 0xd2, 0x0d, 0xf9, 0xe2, 0x18, 0x5b, 0xc6, 0x4e, 0x15, 0xf3,
+	0x25, 0x7a, 0x0e, 0xe6, 0x47, 0x3f, 0xca, 0x89, 0xb3, 0xb0, 0x65, 0xec, 0x2c, 0xed, 0xad, 0x49,
+	0x52, 0xa3, 0xc9, 0x18, 0x0d, 0x7b, 0x39, 0x23, 0x57, 0x7c, 0x13, 0x4b, 0x8c, 0xf7, 0x9f, 0x01,
+	0xd0, 0x4e, 0x86, 0x98, 0xfc, 0x9d, 0x93, 0x8c, 0x21, 0x07, 0x2a, 0xea, 0x28, 0x55, 0x51, 0x87,
+	0xe8, 0x07, 0x30, 0x23, 0xf2, 0x91, 0x44, 0xa2, 0xea, 0xca, 0xde, 0xaa, 0xaa, 0xda, 0x4e, 0x86,
+	0x6d, 0x9e, 0xc6, 0x72, 0x17, 0x3d, 0x05, 0x6b, 0xc0, 0x75, 0x65, 0x4e, 0x69, 0xab, 0xb4, 0xb3,
+	0x54, 0xc4, 0x09, 0xbd, 0x58, 0x6d, 0xa3, 0x06, 0x94, 0xf9, 0x2d, 0x9d, 0xb2, 0x10, 0xe9, 0x36,
+	0x64, 0x0b, 0x1a, 0xba, 0x05, 0x8d, 0x0b, 0xdd, 0x02, 0x2c, 0x70, 0x68, 0x1d, 0xac, 0xbe, 0x1f,
+	0x45, 0x84, 0x3a, 0xa6, 0x10, 0xa6, 0x22, 0xaf, 0x06, 0x4b, 0x42, 0x7f, 0x96, 0x26, 0x71, 0x46,
+	0xbc, 0x3f, 0x45, 0x6b, 0xde, 0xfa, 0xac, 0xff, 0x17, 0x7a, 0x0e, 0x15, 0x4a, 0xfa, 0x09, 0x0d,
+	0x32, 0xc7, 0x10, 0x62, 0xbe, 0x9e, 0x88, 0x51, 0x17, 0xc6, 0x1a, 0xc1, 0x6f, 0x1e, 0xd0, 0x24,
+	0x4d, 0x49, 0x20, 0x6e, 0x58, 0xc6, 0x3a, 0xf4, 0xd6, 0xa1, 0x7e, 0xcd, 0xeb, 0x8d, 0xaf, 0x2a,
+	0xa9, 0xde, 0x6b, 0x58, 0xd1, 0xa9, 0xcb, 0x34, 0xf0, 0x59, 0xa1, 0x47, 0xc6, 0x7d, 0x3d, 0xf2,
+	0xfe, 0x59, 0x80, 0xe5, 0xf7, 0x84, 0xd1, 0xb0, 0xdf, 0xf5, 0x47, 0x69, 0xc4, 0x79, 0xe5, 0x9b,
+	0x30, 0x0e, 0x14, 0x4d, 0xab, 0x94, 0x90, 0xd3, 0x30, 0x0e, 0xb0, 0xd8, 0x46, 0x08, 0xca, 0xb1,
+	0x3f, 0x92, 0xef, 0x5a, 0xc5, 0x62, 0x8d, 0x5e, 0x83, 0x15, 0xf9, 0x3d, 0x12, 0xe9, 0x7e, 0x6f,
+	0x4e, 0x91, 0x65, 0xfd, 0x46, 0x5b, 0x20, 0x5a, 0x31, 0xa3, 0x5f, 0xb0, 0x82, 0xa3, 0xba, 0x9e,
+	0x12, 0xfe, 0x00, 0x86, 0x1a, 0x07, 0xde, 0x85, 0x5e, 0xde, 0xbf, 0x21, 0x2c, 0x73, 0xac, 0xad,
+	0x12, 0xef, 0x82, 0x0a, 0x39, 0xbe, 0x9f, 0xe4, 0x31, 0x73, 0x2a, 0xa2, 0x3b, 0x32, 0x70, 0x7f,
+	0x83, 0xa5, 0x42, 0xf1, 0x39, 0xc3, 0x58, 0x2f, 0x0e, 0x63, 0x55, 0x1d, 0xf3, 0xfb, 0xc2, 0xaf,
+	0xc6, 0x49, 0x79, 0xd1, 0xb4, 0x2d, 0xef, 0x03, 0xd4, 0xb1, 0x78, 0x01, 0x29, 0x38, 0xd3, 0x83,
+	0xf8, 0x13, 0x54, 0x32, 0x21, 0x5e, 0xbf, 0xdd, 0x93, 0x39, 0x17, 0xc3, 0x1a, 0x73, 0xcf, 0xeb,
+	0x6d, 0xc0, 0xda, 0xcc, 0x01, 0x6a, 0x52, 0xb6, 0x60, 0xf9, 0xf4, 0xea, 0x88, 0x30, 0x7d, 0xe2,
+	0x2d, 0xed, 0xde, 0x1b, 0xa8, 0x29, 0x84, 0xa4, 0x4c, 0x2e, 0xc3, 0x41, 0xcb, 0xba, 0x67, 0x75,
+	0x30, 0x07, 0x49, 0x1e, 0xcb, 0x93, 0x17, 0xb1, 0x0c, 0xbc, 0x5f, 0x78, 0xf9, 0x4e, 0x7e, 0x77,
+	0xf9, 0xe9, 0xd6, 0xe8, 0x6a, 0xde, 0x2a, 0xd4, 0x14, 0x4f, 0xe9, 0xdc, 0x86, 0xd5, 0xd3, 0xab,
+	0x03, 0x12, 0x11, 0x46, 0xee, 0x96, 0x8a, 0xc0, 0x9e, 0x80, 0x14, 0xf1, 0x29, 0xaf, 0xd4, 0x0e,
+	0xb3, 0xb1, 0x84, 0x75, 0xb0, 0x52, 0x4a, 0x06, 0xe1, 0x67, 0xc5, 0x54, 0x91, 0xf7, 0x02, 0xac,
+	0xd3, 0xab, 0x8e, 0x1f, 0xd2, 0x07, 0x8b, 0x7c, 0x05, 0x2b, 0xba, 0xb4, 0x6a, 0xcd, 0x36, 0x98,
+	0xa9, 0x1f, 0x52, 0xfd, 0x5a, 0x35, 0xf5, 0x5a, 0xb2, 0x2e, 0x96, 0x7b, 0x1e, 0x85, 0x8d, 0xd3,
+	0xab, 0xfd, 0x64, 0x94, 0xfa, 0x94, 0x34, 0xe3, 0xa0, 0xfb, 0xc9, 0x4f, 0xef, 0x6e, 0x8f, 0x0d,
+	0xa5, 0x24, 0x0a, 0xd4, 0xb9, 0x7c, 0x89, 0xbe, 0x03, 0x48, 0xa2, 0xe0, 0x03, 0xf9, 0x1c, 0x66,
+	0x8c, 0xcf, 0x3b, 0xef, 0x76, 0x35, 0x89, 0x82, 0x96, 0x48, 0x4c, 0x4f, 0xf4, 0x58, 0xea, 0xcf,
+	0xe0, 0xdc, 0x3e, 0x53, 0x89, 0x76, 0xa0, 0x92, 0x7d, 0xf2, 0xc5, 0xd4, 0x18, 0xa2, 0x9a, 0x0e,
+	0xbd, 0x6d, 0xa8, 0x75, 0x49, 0x9f, 0x4e, 0xa6, 0x43, 0xff, 0x7b, 0xc6, 0xe4, 0xdf, 0xf3, 0xfe,
+	0x80, 0x15, 0x0d, 0x9a, 0x37, 0x20, 0xd5, 0x7b, 0x07, 0xe4, 0xd9, 0x89, 0x70, 0x2a, 0x61, 0x0c,
+	0x68, 0x11, 0xca, 0xc7, 0x67, 0x87, 0xe7, 0xf6, 0x57, 0xa8, 0x0a, 0xe6, 0x41, 0xeb, 0xed, 0xe5,
+	0x91, 0x6d, 0xf0, 0xe4, 0x75, 0x13, 0x9f, 0xd9, 0x0b, 0x3c, 0xd9, 0xc2, 0xf8, 0x1c, 0xdb, 0x25,
+	0xbe, 0x3c, 0x6c, 0x5e, 0x34, 0xdb, 0x76, 0x99, 0x2f, 0x3b, 0xcd, 0xb3, 0xe3, 0x7d, 0xdb, 0x7c,
+	0xf6, 0x12, 0x60, 0xe2, 0x16, 0x68, 0x09, 0x2a, 0xfb, 0xe7, 0x97, 0x67, 0x17, 0x2d, 0x2c, 0x0b,
+	0x1e, 0x35, 0x2f, 0x8f, 0x5a, 0xb6, 0x81, 0x6a, 0x50, 0x7d, 0x77, 0xdc, 0xbd, 0x38, 0x3f, 0xc2,
+	0xcd, 0xf7, 0xf6, 0xc2, 0xde, 0xbf, 0x15, 0x80, 0x2e, 0xcb, 0x7b, 0xef, 0x48, 0x94, 0x12, 0x8a,
+	0x7e, 0x84, 0x52, 0x3b, 0x19, 0xa2, 0xdb, 0x1e, 0xe9, 0xa2, 0x62, 0x4a, 0xdd, 0x74, 0x0f, 0xaa,
+	0xed, 0x64, 0xd8, 0x65, 0x94, 0xf8, 0x23, 0x54, 0x30, 0x3a, 0xe1, 0xbc, 0xf3, 0x18, 0x3b, 0x06,
+	0x6a, 0x41, 0x6d, 0xca, 0x48, 0xd1, 0x37, 0x0a, 0x36, 0xcf, 0x5e, 0xdd, 0xb5, 0x19, 0xf7, 0x94,
+	0x1e, 0xfb, 0xc2, 0x40, 0xbb, 0x60, 0x75, 0x68, 0x18, 0xb3, 0xc1, 0x43, 0xb5, 0xee, 0x82, 0x75,
+	0xe8, 0x33, 0x3f, 0x7a, 0x0c, 0xa1, 0xe3, 0xc7, 0x61, 0xff, 0x31, 0x84, 0x03, 0xd2, 0xcb, 0x87,
+	0x0f, 0x26, 0x34, 0xc0, 0xbc, 0xf6, 0x69, 0xfc, 0x98, 0x03, 0x5a, 0x94, 0x26, 0xf4, 0xc1, 0x84,
+	0x13, 0xa8, 0x4d, 0xd9, 0xde, 0xb8, 0xd7, 0xf3, 0xdc, 0xd6, 0xfd, 0x76, 0xfe, 0xe6, 0xf8, 0xad,
+	0x4d, 0xe1, 0x83, 0xe8, 0xc9, 0xf8, 0xaf, 0x9e, 0xf8, 0xa6, 0x5b, 0x9f, 0x4e, 0x16, 0x39, 0x9d,
+	0xbc, 0xc8, 0xe9, 0xe4, 0x73, 0x38, 0x05, 0xa7, 0x43, 0x6f, 0x60, 0x51, 0x9b, 0x18, 0x5a, 0x1f,
+	0x23, 0xa6, 0xac, 0xcf, 0xdd, 0xb8, 0x95, 0x57, 0xe4, 0x57, 0x60, 0x49, 0x4b, 0x42, 0x93, 0xe2,
+	0x05, 0xf3, 0x73, 0xd7, 0x66, 0xb2, 0x8a, 0xd6, 0x05, 0x7b, 0xd6, 0x1e, 0xd0, 0xf7, 0x63, 0xe8,
+	0x5c, 0xaf, 0x72, 0x37, 0xef, 0xdc, 0x9f, 0x68, 0x91, 0xc6, 0x30, 0xd6, 0x32, 0x65, 0x26, 0xee,
+	0xda, 0x4c, 0x56, 0xd2, 0x7a, 0x96, 0xc8, 0xbe, 0xfc, 0x7f, 0x00, 0x32, 0xff, 0x1e, 0x35, 0x22,
+	0x0a, 0x00, 0x00,
 }
@@ -0,0 +1,147 @@
+package adapter
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrSecretNotFound is returned by Secrets.Secret if there is no secret with the name.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Secrets provides secrets by name. Hosts plug a Secrets into the stubs they create with WithSecrets.
+type Secrets interface {
+	// Secret returns the value of the secret called `name`, or ErrSecretNotFound if there is none
+	Secret(name string) (value string, err error)
+}
+
+// MapSecrets provides the secrets in the map, which is useful in tests.
+type MapSecrets map[string]string
+
+func (m MapSecrets) Secret(name string) (string, error) {
+	value, ok := m[name]
+
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+
+	return value, nil
+}
+
+// EnvSecrets provides secrets from environment variables. The variable of a secret is its name, upper-cased and with
+// every character that is not a letter or digit replaced by an underscore, prefixed with `prefix`. With prefix
+// "ADAPTER_SECRET_", the secret "db-password" is read from ADAPTER_SECRET_DB_PASSWORD.
+func EnvSecrets(prefix string) Secrets {
+	return envSecrets(prefix)
+}
+
+type envSecrets string
+
+func (prefix envSecrets) Secret(name string) (string, error) {
+	variable := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, name)
+
+	value, ok := os.LookupEnv(string(prefix) + variable)
+
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+
+	return value, nil
+}
+
+// FileSecrets provides secrets from the files in `dir`, one file per secret named after it, the way container
+// orchestrators mount secrets. A single trailing newline is stripped from the value.
+func FileSecrets(dir string) Secrets {
+	return fileSecrets(dir)
+}
+
+type fileSecrets string
+
+func (dir fileSecrets) Secret(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", ErrSecretNotFound
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(string(dir), name))
+
+	if os.IsNotExist(err) {
+		return "", ErrSecretNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSuffix(string(data), "\n")
+
+	return strings.TrimSuffix(value, "\r"), nil
+}
+
+const redacted = "[REDACTED]"
+
+// redactor replaces the secret values it has been given with a placeholder. It is safe for concurrent use.
+type redactor struct {
+	mu       sync.RWMutex
+	values   map[string]bool
+	replacer *strings.Replacer
+}
+
+func (r *redactor) add(value string) {
+	if value == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.values[value] {
+		return
+	}
+
+	if r.values == nil {
+		r.values = make(map[string]bool)
+	}
+
+	r.values[value] = true
+
+	var values []string
+
+	for value := range r.values {
+		values = append(values, value)
+	}
+
+	// longer values go first, so that a secret containing another one is redacted completely
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	var oldnew []string
+
+	for _, value := range values {
+		oldnew = append(oldnew, value, redacted)
+	}
+
+	r.replacer = strings.NewReplacer(oldnew...)
+}
+
+func (r *redactor) redact(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.replacer == nil {
+		return s
+	}
+
+	return r.replacer.Replace(s)
+}
@@ -0,0 +1,111 @@
+package adapter
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc"
+)
+
+func testSecrets(t *testing.T, secrets Secrets, name, expected string) {
+	value, err := secrets.Secret(name)
+
+	if err != nil || value != expected {
+		t.Fatalf("expected secret %q to be %q, got %q, %v", name, expected, value, err)
+	}
+
+	if _, err := secrets.Secret("missing"); err != ErrSecretNotFound {
+		t.Fatalf("expected %v, got %v", ErrSecretNotFound, err)
+	}
+}
+
+func TestMapSecrets(t *testing.T) {
+	testSecrets(t, MapSecrets{"db-password": "hunter2"}, "db-password", "hunter2")
+}
+
+func TestEnvSecrets(t *testing.T) {
+	os.Setenv("ADAPTER_TEST_SECRET_DB_PASSWORD", "hunter2")
+	defer os.Unsetenv("ADAPTER_TEST_SECRET_DB_PASSWORD")
+
+	testSecrets(t, EnvSecrets("ADAPTER_TEST_SECRET_"), "db-password", "hunter2")
+}
+
+func TestFileSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "db-password"), []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	secrets := FileSecrets(dir)
+	testSecrets(t, secrets, "db-password", "hunter2")
+
+	if _, err := secrets.Secret("../" + filepath.Base(dir) + "/db-password"); err != ErrSecretNotFound {
+		t.Fatalf("expected secrets outside of the directory to be %v, got %v", ErrSecretNotFound, err)
+	}
+}
+
+func TestGRPCStubSecretRedaction(t *testing.T) {
+	log := &logStub{logs: make(chan string, 2)}
+	host := NewStub(log, WithSecrets(MapSecrets{"token": "s3cr3t", "long-token": "s3cr3t-and-more"}))
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		proto.RegisterStubHelperServer(s, &GRPCStubServer{Impl: host})
+	})
+
+	defer server.Stop()
+	defer conn.Close()
+
+	stub := &GRPCStubHelperClient{client: proto.NewStubHelperClient(conn)}
+
+	testSecrets(t, stub, "token", "s3cr3t")
+	testSecrets(t, stub, "long-token", "s3cr3t-and-more")
+
+	stub.Printf("connecting with %s and %s", "s3cr3t", "s3cr3t-and-more")
+
+	if expected, log := "connecting with [REDACTED] and [REDACTED]", <-log.logs; log != expected {
+		t.Fatalf("expected log %q, got %q", expected, log)
+	}
+}
+
+func TestStubRedactsFields(t *testing.T) {
+	log := &recordLogger{records: make(chan logger.Record, 1)}
+	host := NewStub(log, WithSecrets(MapSecrets{"token": "s3cr3t"})).(*stub)
+
+	if _, err := host.Secret("token"); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+
+	host.LogRecord(logger.Record{
+		Message: "connecting",
+		Fields: []logger.Field{
+			{Key: "string", Value: "s3cr3t"},
+			{Key: "bytes", Value: []byte("s3cr3t")},
+			{Key: "attribute", Value: BytesAttr([]byte("s3cr3t"))},
+			{Key: "error", Value: errors.New("bad token s3cr3t")},
+			{Key: "stringer", Value: StringAttr("s3cr3t")},
+			{Key: "int", Value: 1},
+		},
+	})
+
+	record := <-log.records
+	expected := []interface{}{redacted, redacted, redacted, "bad token " + redacted, redacted, 1}
+
+	for i, field := range record.Fields {
+		if field.Value != expected[i] {
+			t.Fatalf("expected field %q to be %v, got %v", field.Key, expected[i], field.Value)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package adapter
+
+// StartOption configures how StartEndpoint and StartAction serve a plugin.
+type StartOption func(*startConfig)
+
+type startConfig struct {
+	limits   Limits
+	manifest Manifest
+}
+
+// WithLimits sets the limits on the message bodies that the plugin receives and returns.
+func WithLimits(limits Limits) StartOption {
+	return func(c *startConfig) {
+		c.limits = limits
+	}
+}
+
+func newStartConfig(opts []StartOption) *startConfig {
+	c := &startConfig{}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
@@ -0,0 +1,76 @@
+package adapter
+
+import "context"
+
+// NewStreamingEndpoint returns `endpoint` itself if it already implements StreamingEndpoint. Otherwise it returns a
+// StreamingEndpoint that implements ReceiveStream by calling Receive in a loop.
+func NewStreamingEndpoint(endpoint Endpoint) StreamingEndpoint {
+	if streaming, ok := endpoint.(StreamingEndpoint); ok {
+		return streaming
+	}
+
+	return &receiveLoop{Endpoint: endpoint}
+}
+
+type receiveLoop struct {
+	Endpoint
+}
+
+func (r *receiveLoop) ReceiveStream(stub Stub, messages chan<- *TaggedMessage) error {
+	for {
+		message, err := r.Receive(stub)
+
+		if err != nil {
+			return err
+		}
+
+		messages <- message
+	}
+}
+
+// drainMessages Nacks messages with `reason` until the ReceiveStream call producing them has returned, so that it never
+// blocks on a stream nobody is reading anymore, and the messages it already received are redelivered.
+func drainMessages(endpoint ContextEndpoint, stub Stub, messages <-chan *TaggedMessage, done <-chan error, reason error) {
+	for {
+		select {
+		case message := <-messages:
+			endpoint.NackContext(context.Background(), stub, message.Tag, reason)
+		case <-done:
+			return
+		}
+	}
+}
+
+// receiveStream sends the messages received by `endpoint` on `messages` until receiving fails or `ctx` is done, using
+// the most capable receive method that `endpoint` implements. A plain StreamingEndpoint cannot be cancelled, so the
+// caller must still stop reading once `ctx` is done and drain `messages`.
+func receiveStream(ctx context.Context, endpoint Endpoint, stub Stub, messages chan<- *TaggedMessage) error {
+	switch e := endpoint.(type) {
+	case ContextStreamingEndpoint:
+		return e.ReceiveStreamContext(ctx, stub, messages)
+	case StreamingEndpoint:
+		return e.ReceiveStream(stub, messages)
+	}
+
+	return receiveEach(ctx, NewContextEndpoint(endpoint), stub, messages)
+}
+
+// receiveEach sends the messages received by `ce` with one Receive per message on `messages`, until receiving fails
+// or `ctx` is done.
+func receiveEach(ctx context.Context, ce ContextEndpoint, stub Stub, messages chan<- *TaggedMessage) error {
+	for {
+		message, err := ce.ReceiveContext(ctx, stub)
+
+		if err != nil {
+			return err
+		}
+
+		select {
+		case messages <- message:
+		case <-ctx.Done():
+			ce.NackContext(context.Background(), stub, message.Tag, ctx.Err())
+
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package adapter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/unchainio/interfaces/logger"
+)
+
+// NewStub composes the Stub that a host hands to a plugin instance. Logging goes to `log`, with the values of all
+// secrets that have been handed out by the stub redacted. The stores of the stub are configured through `opts`; they
+// default to an empty in-memory KV, no secrets and DiscardMetrics.
+func NewStub(log logger.Logger, opts ...StubOption) Stub {
+	s := &stub{
+		log:     log,
+		KV:      NewMemoryKV(),
+		secrets: MapSecrets{},
+		Metrics: DiscardMetrics,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+type StubOption func(s *stub)
+
+// WithKV makes the stub use `kv` as its key-value store, e.g. a FileKV for state that has to survive host restarts.
+func WithKV(kv KV) StubOption {
+	return func(s *stub) {
+		s.KV = kv
+	}
+}
+
+// WithSecrets makes the stub provide the secrets of `secrets`, e.g. EnvSecrets or FileSecrets.
+func WithSecrets(secrets Secrets) StubOption {
+	return func(s *stub) {
+		s.secrets = secrets
+	}
+}
+
+// WithMetrics makes the stub record the metrics of the plugin to `metrics`, e.g. a view of a MetricsRegistry with
+// labels of the plugin instance, see MetricsRegistry.With.
+func WithMetrics(metrics Metrics) StubOption {
+	return func(s *stub) {
+		s.Metrics = metrics
+	}
+}
+
+type stub struct {
+	log logger.Logger
+	KV
+	Metrics
+	secrets  Secrets
+	redactor redactor
+}
+
+func (s *stub) Secret(name string) (string, error) {
+	value, err := s.secrets.Secret(name)
+
+	if err != nil {
+		return "", err
+	}
+
+	s.redactor.add(value)
+
+	return value, nil
+}
+
+func (s *stub) MergeHistogram(name string, buckets []uint64, sum float64, count uint64, labels Labels) {
+	mergeHistogram(s.Metrics, name, buckets, sum, count, labels)
+}
+
+// Level returns the lowest level that the logger of the stub logs.
+func (s *stub) Level() logger.Level {
+	return logger.LevelOf(s.log)
+}
+
+func (s *stub) Printf(format string, v ...interface{}) {
+	s.log.Printf("%s", s.redactor.redact(fmt.Sprintf(format, v...)))
+}
+
+// Fatalf logs at the error level instead of ending the host, see FatalError.
+func (s *stub) Fatalf(format string, v ...interface{}) {
+	s.LogRecord(logger.Record{Time: time.Now(), Level: logger.FatalLevel, Message: fmt.Sprintf(format, v...)})
+}
+
+// Panicf logs at the error level instead of panicking, see FatalError.
+func (s *stub) Panicf(format string, v ...interface{}) {
+	s.LogRecord(logger.Record{Time: time.Now(), Level: logger.PanicLevel, Message: fmt.Sprintf(format, v...)})
+}
+
+func (s *stub) Debugf(format string, v ...interface{}) {
+	s.log.Debugf("%s", s.redactor.redact(fmt.Sprintf(format, v...)))
+}
+
+func (s *stub) Warnf(format string, v ...interface{}) {
+	s.log.Warnf("%s", s.redactor.redact(fmt.Sprintf(format, v...)))
+}
+
+func (s *stub) Errorf(format string, v ...interface{}) {
+	s.log.Errorf("%s", s.redactor.redact(fmt.Sprintf(format, v...)))
+}
+
+// LogRecord logs `record` with its message and fields redacted, keeping its fields, time and caller if the logger of
+// the stub is a logger.RecordLogger. Records at the fatal or panic level are logged at the error level.
+func (s *stub) LogRecord(record logger.Record) {
+	record = downgradeFatal(record)
+	record.Message = s.redactor.redact(record.Message)
+	record.Fields = append([]logger.Field(nil), record.Fields...)
+
+	for i, field := range record.Fields {
+		record.Fields[i].Value = s.redactField(field.Value)
+	}
+
+	logger.WriteRecord(s.log, record)
+}
+
+// redactField redacts the value of a log field. Bytes, errors and fmt.Stringers end up in the log as text, so a value
+// whose text contains a secret is replaced by its redacted text.
+func (s *stub) redactField(value interface{}) interface{} {
+	var text string
+
+	switch v := value.(type) {
+	case string:
+		return s.redactor.redact(v)
+	case []byte:
+		text = string(v)
+	case AttributeValue:
+		if raw, ok := v.BytesValue(); ok {
+			text = string(raw)
+		} else {
+			text = v.String()
+		}
+	case error:
+		text = v.Error()
+	case fmt.Stringer:
+		text = v.String()
+	default:
+		return value
+	}
+
+	if redacted := s.redactor.redact(text); redacted != text {
+		return redacted
+	}
+
+	return value
+}
@@ -2,84 +2,359 @@ package adapter
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"sync"
 
-	"github.com/hashicorp/go-plugin"
 	"github.com/unchainio/interfaces/adapter/proto"
+	"github.com/unchainio/interfaces/logger"
 	"google.golang.org/grpc"
 )
 
-func SetupStubServer(stub Stub, broker *plugin.GRPCBroker) (brokerID uint32, close func()) {
-	stubHelperServer := &GRPCStubServer{Impl: stub}
+// Broker connects the host to the stub servers that it serves for a plugin. A *plugin.GRPCBroker is the broker of a
+// plugin running in a process of its own; plugins running in-process use an in-memory broker.
+type Broker interface {
+	NextId() uint32
+	AcceptAndServe(id uint32, s func([]grpc.ServerOption) *grpc.Server)
+	Dial(id uint32) (*grpc.ClientConn, error)
+}
 
-	var s *grpc.Server
-	serverFunc := func(opts []grpc.ServerOption) *grpc.Server {
-		s = grpc.NewServer(opts...)
-		proto.RegisterStubHelperServer(s, stubHelperServer)
+func SetupStubServer(stub Stub, broker Broker) (brokerID uint32, close func()) {
+	return setupStubServer(stub, broker, nil, nil)
+}
 
-		return s
-	}
+// setupStubServer serves `stub`, recording the Fatalf and Panicf calls of the plugin in `h` and pushing `level` to
+// the plugin if they are set.
+func setupStubServer(stub Stub, broker Broker, h *health, level *logLevel) (brokerID uint32, close func()) {
+	server := &stubServer{impl: &GRPCStubServer{Impl: stub, health: h, level: level}}
 
 	brokerID = broker.NextId()
-	go broker.AcceptAndServe(brokerID, serverFunc)
+	go broker.AcceptAndServe(brokerID, server.serve)
+
+	return brokerID, server.stop
+}
+
+// stubServer is a StubHelper server that can be stopped before the broker has created it.
+type stubServer struct {
+	impl *GRPCStubServer
 
-	return brokerID, func() { s.Stop() }
+	mu      sync.Mutex
+	server  *grpc.Server
+	stopped bool
 }
 
-func SetupStubClient(broker *plugin.GRPCBroker, brokerID uint32) (stub Stub, close func(), err error) {
+func (s *stubServer) serve(opts []grpc.ServerOption) *grpc.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.server = grpc.NewServer(opts...)
+	proto.RegisterStubHelperServer(s.server, s.impl)
+
+	if s.stopped {
+		s.server.Stop()
+	}
+
+	return s.server
+}
+
+func (s *stubServer) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+
+	if s.server != nil {
+		s.server.Stop()
+	}
+}
+
+// errNoStubServer is returned to hosts that call a plugin without serving a stub for it. Broker IDs start at 1, so
+// such a call has a StubServer of 0.
+var errNoStubServer = errors.New("adapter: the host did not provide a stub server")
+
+func SetupStubClient(broker Broker, brokerID uint32) (stub Stub, close func(), err error) {
+	if brokerID == 0 {
+		return nil, nil, errNoStubServer
+	}
+
 	conn, err := broker.Dial(brokerID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	stub = &GRPCStubHelperClient{proto.NewStubHelperClient(conn)}
+	// plugins served in-process share their process with the host, which Fatalf and Panicf must not end
+	_, inProcess := broker.(*memoryBroker)
+
+	helper := &GRPCStubHelperClient{client: proto.NewStubHelperClient(conn), exits: !inProcess}
+	helper.logs = newLogPipeline(helper.client, helper.send, DefaultLogBufferSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	helper.watchLogLevel(ctx)
+
+	return helper, func() {
+		cancel()
+		helper.logs.close()
+		helper.flushMetrics()
+		conn.Close()
+	}, nil
+}
+
+// persistentStubServer is the host side of the long-lived stub connection of a dispensed plugin. The stub passed at
+// Init is served until the plugin is closed, and every later call reuses it. Calls made before Init fall back to a
+// stub server for the duration of the call.
+type persistentStubServer struct {
+	mu   sync.Mutex
+	id   uint32
+	stop func()
+
+	health health
+	level  logLevel
+}
+
+// open starts serving `stub` as the persistent stub, replacing the previous one, and returns its broker ID.
+func (p *persistentStubServer) open(broker Broker, stub Stub) uint32 {
+	p.close()
+
+	id, stop := setupStubServer(stub, broker, &p.health, &p.level)
+
+	p.mu.Lock()
+	p.id, p.stop = id, stop
+	p.mu.Unlock()
+
+	return id
+}
+
+// get returns the broker ID of the persistent stub, or serves `stub` until the returned function is called if there
+// is none.
+func (p *persistentStubServer) get(broker Broker, stub Stub) (brokerID uint32, close func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.id != 0 {
+		return p.id, func() {}
+	}
+
+	return setupStubServer(stub, broker, &p.health, &p.level)
+}
+
+// close stops serving the persistent stub.
+func (p *persistentStubServer) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stop != nil {
+		p.stop()
+	}
+
+	p.id, p.stop = 0, nil
+}
 
-	return stub, func() { conn.Close() }, nil
+// persistentStubClient is the plugin side of the long-lived stub connection. The stub dialed at Init stays connected
+// until the plugin is closed; stubs with any other broker ID are dialed for the duration of a single call.
+type persistentStubClient struct {
+	mu     sync.Mutex
+	id     uint32
+	stub   Stub
+	closer func()
+}
+
+// open dials the stub with broker ID `brokerID` and keeps it as the persistent stub, replacing the previous one.
+func (p *persistentStubClient) open(broker Broker, brokerID uint32) (Stub, error) {
+	stub, closer, err := SetupStubClient(broker, brokerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closer != nil {
+		p.closer()
+	}
+
+	p.id, p.stub, p.closer = brokerID, stub, closer
+
+	return stub, nil
+}
+
+// get returns the persistent stub if it has broker ID `brokerID`, or dials that stub until the returned function
+// is called. The logs and metrics of the persistent stub are delivered in the background, and only flushed at Init,
+// when it is disconnected, and when the plugin exits.
+func (p *persistentStubClient) get(broker Broker, brokerID uint32) (stub Stub, close func(), err error) {
+	p.mu.Lock()
+
+	if p.stub != nil && p.id == brokerID {
+		defer p.mu.Unlock()
+
+		return p.stub, func() {}, nil
+	}
+
+	p.mu.Unlock()
+
+	return SetupStubClient(broker, brokerID)
+}
+
+// flush delivers the buffered logs of the persistent stub, so that the host has the ones logged at Init by the time
+// Init returns.
+func (p *persistentStubClient) flush() {
+	p.mu.Lock()
+	stub := p.stub
+	p.mu.Unlock()
+
+	if helper, ok := stub.(*GRPCStubHelperClient); ok && helper.logs != nil {
+		helper.logs.flush()
+	}
+}
+
+// disconnect closes the connection to the persistent stub, after delivering its buffered logs and metrics.
+func (p *persistentStubClient) disconnect() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closer != nil {
+		p.closer()
+	}
+
+	p.id, p.stub, p.closer = 0, nil, nil
 }
 
 // GRPCClient is an implementation of KV that talks over RPC.
-type GRPCStubHelperClient struct{ client proto.StubHelperClient }
+type GRPCStubHelperClient struct {
+	client proto.StubHelperClient
+
+	// exits is set if the plugin runs in a process of its own, which Fatalf and Panicf terminate
+	exits bool
+
+	// legacyLog is set once the host turned out to predate the Log RPC
+	legacyLog int32
+
+	// logs buffers the log records of the stub; without it, every record is sent right away
+	logs *logPipeline
+
+	// level is the logger.Level below which records are dropped, as pushed by the host
+	level int32
+
+	// metrics buffers the metrics of the stub until flushMetrics, which metricsMu serializes
+	metrics   metricsBuffer
+	metricsMu sync.Mutex
+
+	// legacyMetrics is set once the host turned out to predate the RecordMetrics RPC
+	legacyMetrics int32
+}
 
 func (m *GRPCStubHelperClient) Debugf(format string, v ...interface{}) {
-	m.client.Debugf(context.Background(), &proto.LogRequest{
-		Message: fmt.Sprintf(format, v...),
-	})
+	m.log(logger.DebugLevel, format, v...)
 }
 
 func (m *GRPCStubHelperClient) Errorf(format string, v ...interface{}) {
-	m.client.Errorf(context.Background(), &proto.LogRequest{
-		Message: fmt.Sprintf(format, v...),
-	})
+	m.log(logger.ErrorLevel, format, v...)
 }
 
 func (m *GRPCStubHelperClient) Fatalf(format string, v ...interface{}) {
-	m.client.Fatalf(context.Background(), &proto.LogRequest{
-		Message: fmt.Sprintf(format, v...),
-	})
+	m.log(logger.FatalLevel, format, v...)
 }
 
 func (m *GRPCStubHelperClient) Panicf(format string, v ...interface{}) {
-	m.client.Panicf(context.Background(), &proto.LogRequest{
-		Message: fmt.Sprintf(format, v...),
-	})
+	m.log(logger.PanicLevel, format, v...)
 }
 
 func (m *GRPCStubHelperClient) Printf(format string, v ...interface{}) {
-	m.client.Printf(context.Background(), &proto.LogRequest{
-		Message: fmt.Sprintf(format, v...),
-	})
+	m.log(logger.InfoLevel, format, v...)
 }
 
 func (m *GRPCStubHelperClient) Warnf(format string, v ...interface{}) {
-	m.client.Warnf(context.Background(), &proto.LogRequest{
-		Message: fmt.Sprintf(format, v...),
+	m.log(logger.WarnLevel, format, v...)
+}
+
+func (m *GRPCStubHelperClient) Get(key string) ([]byte, error) {
+	r, err := m.client.KVGet(context.Background(), &proto.KVGetRequest{
+		Key: key,
 	})
+
+	if err != nil {
+		return nil, errorFromStatus(err)
+	}
+
+	if !r.Found {
+		return nil, ErrKeyNotFound
+	}
+
+	return copyBytes(r.Value), nil
+}
+
+func (m *GRPCStubHelperClient) Put(key string, value []byte) error {
+	_, err := m.client.KVPut(context.Background(), &proto.KVPutRequest{
+		Key:   key,
+		Value: value,
+	})
+
+	return errorFromStatus(err)
+}
+
+func (m *GRPCStubHelperClient) Delete(key string) error {
+	_, err := m.client.KVDelete(context.Background(), &proto.KVDeleteRequest{
+		Key: key,
+	})
+
+	return errorFromStatus(err)
+}
+
+func (m *GRPCStubHelperClient) List(prefix string) ([]KVPair, error) {
+	r, err := m.client.KVList(context.Background(), &proto.KVListRequest{
+		Prefix: prefix,
+	})
+
+	if err != nil {
+		return nil, errorFromStatus(err)
+	}
+
+	var pairs []KVPair
+
+	for _, pair := range r.Pairs {
+		pairs = append(pairs, KVPair{Key: pair.Key, Value: copyBytes(pair.Value)})
+	}
+
+	return pairs, nil
+}
+
+func (m *GRPCStubHelperClient) CompareAndSwap(key string, old, value []byte) (bool, error) {
+	r, err := m.client.KVCompareAndSwap(context.Background(), &proto.KVCompareAndSwapRequest{
+		Key:       key,
+		Old:       old,
+		OldExists: old != nil,
+		Value:     value,
+	})
+
+	if err != nil {
+		return false, errorFromStatus(err)
+	}
+
+	return r.Swapped, nil
+}
+
+func (m *GRPCStubHelperClient) Secret(name string) (string, error) {
+	r, err := m.client.Secret(context.Background(), &proto.SecretRequest{
+		Name: name,
+	})
+
+	if err != nil {
+		return "", errorFromStatus(err)
+	}
+
+	if !r.Found {
+		return "", ErrSecretNotFound
+	}
+
+	return r.Value, nil
 }
 
 // Here is the gRPC server that GRPCClient talks to.
 type GRPCStubServer struct {
 	// This is the real implementation
 	Impl Stub
+
+	health *health
+	level  *logLevel
 }
 
 func (m *GRPCStubServer) Printf(ctx context.Context, req *proto.LogRequest) (*proto.LogResponse, error) {
@@ -89,17 +364,26 @@ func (m *GRPCStubServer) Printf(ctx context.Context, req *proto.LogRequest) (*pr
 }
 
 func (m *GRPCStubServer) Fatalf(ctx context.Context, req *proto.LogRequest) (*proto.LogResponse, error) {
-	m.Impl.Fatalf("%s", req.Message)
+	m.fatal(logger.Record{Level: logger.FatalLevel, Message: req.Message})
 
 	return &proto.LogResponse{}, nil
 }
 
 func (m *GRPCStubServer) Panicf(ctx context.Context, req *proto.LogRequest) (*proto.LogResponse, error) {
-	m.Impl.Panicf("%s", req.Message)
+	m.fatal(logger.Record{Level: logger.PanicLevel, Message: req.Message})
 
 	return &proto.LogResponse{}, nil
 }
 
+// fatal marks the plugin unhealthy and logs `record` as an error, so that the plugin can't end the host.
+func (m *GRPCStubServer) fatal(record logger.Record) {
+	if m.health != nil {
+		m.health.record(record)
+	}
+
+	logger.WriteRecord(m.Impl, downgradeFatal(record))
+}
+
 func (m *GRPCStubServer) Debugf(ctx context.Context, req *proto.LogRequest) (*proto.LogResponse, error) {
 	m.Impl.Debugf("%s", req.Message)
 
@@ -117,3 +401,81 @@ func (m *GRPCStubServer) Errorf(ctx context.Context, req *proto.LogRequest) (*pr
 
 	return &proto.LogResponse{}, nil
 }
+
+func (m *GRPCStubServer) KVGet(ctx context.Context, req *proto.KVGetRequest) (*proto.KVGetResponse, error) {
+	value, err := m.Impl.Get(req.Key)
+
+	if err == ErrKeyNotFound {
+		return &proto.KVGetResponse{}, nil
+	}
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &proto.KVGetResponse{
+		Value: value,
+		Found: true,
+	}, nil
+}
+
+func (m *GRPCStubServer) KVPut(ctx context.Context, req *proto.KVPutRequest) (*proto.KVPutResponse, error) {
+	return &proto.KVPutResponse{}, errorToStatus(m.Impl.Put(req.Key, req.Value))
+}
+
+func (m *GRPCStubServer) KVDelete(ctx context.Context, req *proto.KVDeleteRequest) (*proto.KVDeleteResponse, error) {
+	return &proto.KVDeleteResponse{}, errorToStatus(m.Impl.Delete(req.Key))
+}
+
+func (m *GRPCStubServer) KVList(ctx context.Context, req *proto.KVListRequest) (*proto.KVListResponse, error) {
+	pairs, err := m.Impl.List(req.Prefix)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	r := &proto.KVListResponse{}
+
+	for _, pair := range pairs {
+		r.Pairs = append(r.Pairs, &proto.KVPair{Key: pair.Key, Value: pair.Value})
+	}
+
+	return r, nil
+}
+
+func (m *GRPCStubServer) KVCompareAndSwap(ctx context.Context, req *proto.KVCompareAndSwapRequest) (*proto.KVCompareAndSwapResponse, error) {
+	old := req.Old
+
+	if req.OldExists {
+		old = copyBytes(old)
+	} else {
+		old = nil
+	}
+
+	swapped, err := m.Impl.CompareAndSwap(req.Key, old, req.Value)
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &proto.KVCompareAndSwapResponse{
+		Swapped: swapped,
+	}, nil
+}
+
+func (m *GRPCStubServer) Secret(ctx context.Context, req *proto.SecretRequest) (*proto.SecretResponse, error) {
+	value, err := m.Impl.Secret(req.Name)
+
+	if err == ErrSecretNotFound {
+		return &proto.SecretResponse{}, nil
+	}
+
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &proto.SecretResponse{
+		Value: value,
+		Found: true,
+	}, nil
+}
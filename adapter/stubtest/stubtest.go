@@ -0,0 +1,156 @@
+// Package stubtest provides a Stub for unit testing endpoints and actions without a host. It records everything
+// logged through it, serves an in-memory KV and secrets, and aggregates the metrics recorded through it. It is safe for
+// concurrent use.
+package stubtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/unchainio/interfaces/adapter"
+	"github.com/unchainio/interfaces/logger"
+)
+
+// Level is the level of a log entry, named after the logger.Logger method it was logged with.
+type Level string
+
+const (
+	DebugLevel Level = "DEBUG"
+	InfoLevel  Level = "INFO"
+	WarnLevel  Level = "WARN"
+	ErrorLevel Level = "ERROR"
+	FatalLevel Level = "FATAL"
+	PanicLevel Level = "PANIC"
+)
+
+// Entry is a single log call.
+type Entry struct {
+	Level   Level
+	Message string
+}
+
+func (e Entry) String() string {
+	return string(e.Level) + " " + e.Message
+}
+
+// Stub is a recording adapter.Stub. Unlike a host stub, Fatalf and Panicf only record the entry, so that the code
+// under test keeps running. The metrics recorded through it can be checked with Value.
+type Stub struct {
+	adapter.KV
+	*adapter.MetricsRegistry
+
+	mu      sync.Mutex
+	entries []Entry
+	secrets map[string]string
+}
+
+var _ adapter.Stub = &Stub{}
+
+// New returns a Stub with an empty in-memory KV, no secrets and no metrics.
+func New() *Stub {
+	return &Stub{
+		KV:              adapter.NewMemoryKV(),
+		MetricsRegistry: adapter.NewMetricsRegistry(),
+		secrets:         make(map[string]string),
+	}
+}
+
+func (s *Stub) record(level Level, format string, v ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, Entry{Level: level, Message: fmt.Sprintf(format, v...)})
+}
+
+// LogRecord records `record` with its fields formatted after the message, as `message key=value ...`.
+func (s *Stub) LogRecord(record logger.Record) {
+	s.record(Level(record.Level.String()), "%s", record)
+}
+
+func (s *Stub) Printf(format string, v ...interface{}) { s.record(InfoLevel, format, v...) }
+func (s *Stub) Fatalf(format string, v ...interface{}) { s.record(FatalLevel, format, v...) }
+func (s *Stub) Panicf(format string, v ...interface{}) { s.record(PanicLevel, format, v...) }
+func (s *Stub) Debugf(format string, v ...interface{}) { s.record(DebugLevel, format, v...) }
+func (s *Stub) Warnf(format string, v ...interface{})  { s.record(WarnLevel, format, v...) }
+func (s *Stub) Errorf(format string, v ...interface{}) { s.record(ErrorLevel, format, v...) }
+
+// SetSecret makes Secret return `value` for `name`.
+func (s *Stub) SetSecret(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.secrets[name] = value
+}
+
+func (s *Stub) Secret(name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.secrets[name]
+
+	if !ok {
+		return "", adapter.ErrSecretNotFound
+	}
+
+	return value, nil
+}
+
+// Entries returns the entries logged so far, in order.
+func (s *Stub) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Entry(nil), s.entries...)
+}
+
+// Reset forgets the entries logged so far.
+func (s *Stub) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = nil
+}
+
+// Logged reports whether an entry at `level` containing `substr` has been logged.
+func (s *Stub) Logged(level Level, substr string) bool {
+	for _, entry := range s.Entries() {
+		if entry.Level == level && strings.Contains(entry.Message, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertLogged fails `t` unless an entry at `level` containing `substr` has been logged.
+func (s *Stub) AssertLogged(t testing.TB, level Level, substr string) {
+	t.Helper()
+
+	if !s.Logged(level, substr) {
+		t.Errorf("expected a %s entry containing %q, got %v", level, substr, s.Entries())
+	}
+}
+
+// AssertNotLogged fails `t` if an entry at `level` containing `substr` has been logged.
+func (s *Stub) AssertNotLogged(t testing.TB, level Level, substr string) {
+	t.Helper()
+
+	if s.Logged(level, substr) {
+		t.Errorf("expected no %s entry containing %q, got %v", level, substr, s.Entries())
+	}
+}
+
+// AssertNoEntriesAt fails `t` if any entry at `level` has been logged, e.g. to check that no errors were logged.
+func (s *Stub) AssertNoEntriesAt(t testing.TB, level Level) {
+	t.Helper()
+
+	for _, entry := range s.Entries() {
+		if entry.Level == level {
+			t.Errorf("expected no %s entries, got %v", level, s.Entries())
+
+			return
+		}
+	}
+}
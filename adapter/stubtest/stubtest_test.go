@@ -0,0 +1,126 @@
+package stubtest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+func TestStubRecordsLevels(t *testing.T) {
+	stub := New()
+
+	stub.Debugf("debug %d", 1)
+	stub.Printf("info %d", 2)
+	stub.Warnf("warn %d", 3)
+	stub.Errorf("error %d", 4)
+	stub.Fatalf("fatal %d", 5)
+	stub.Panicf("panic %d", 6)
+
+	expected := []Entry{
+		{DebugLevel, "debug 1"},
+		{InfoLevel, "info 2"},
+		{WarnLevel, "warn 3"},
+		{ErrorLevel, "error 4"},
+		{FatalLevel, "fatal 5"},
+		{PanicLevel, "panic 6"},
+	}
+
+	entries := stub.Entries()
+
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, entries)
+	}
+
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, entries)
+		}
+	}
+
+	stub.AssertLogged(t, WarnLevel, "warn")
+	stub.AssertNotLogged(t, InfoLevel, "warn")
+
+	stub.Reset()
+	stub.AssertNoEntriesAt(t, ErrorLevel)
+}
+
+func TestStubConcurrentUse(t *testing.T) {
+	stub := New()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			stub.Printf("goroutine %d", i)
+			stub.SetSecret("token", "secret")
+			stub.Put("key", []byte{byte(i)})
+			stub.Entries()
+		}(i)
+	}
+
+	wg.Wait()
+
+	if n := len(stub.Entries()); n != 10 {
+		t.Fatalf("expected 10 entries, got %d", n)
+	}
+}
+
+func TestStubKVAndSecrets(t *testing.T) {
+	stub := New()
+
+	if _, err := stub.Get("cursor"); err != adapter.ErrKeyNotFound {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	if err := stub.Put("cursor", []byte("42")); err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+
+	if value, err := stub.Get("cursor"); err != nil || string(value) != "42" {
+		t.Fatalf("expected %q, got %q, %v", "42", value, err)
+	}
+
+	if _, err := stub.Secret("token"); err != adapter.ErrSecretNotFound {
+		t.Fatalf("expected ErrSecretNotFound, got %v", err)
+	}
+
+	stub.SetSecret("token", "s3cr3t")
+
+	if value, err := stub.Secret("token"); err != nil || value != "s3cr3t" {
+		t.Fatalf("expected %q, got %q, %v", "s3cr3t", value, err)
+	}
+}
+
+// initLogAction logs its config at Init.
+type initLogAction struct{}
+
+func (initLogAction) Init(stub adapter.Stub, config []byte) error {
+	stub.Printf("init with %s", config)
+
+	return nil
+}
+
+func (initLogAction) Invoke(stub adapter.Stub, message *adapter.Message) error { return nil }
+
+func TestStubOverGRPC(t *testing.T) {
+	action, err := adapter.NewInProcessAction(initLogAction{})
+
+	if err != nil {
+		t.Fatalf("failed to serve action in-process: %v", err)
+	}
+
+	defer action.Close()
+
+	stub := New()
+
+	if err := action.Init(stub, []byte("config")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	stub.AssertLogged(t, InfoLevel, "init with config")
+}
@@ -0,0 +1,171 @@
+package host
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/unchainio/interfaces/logger"
+)
+
+// hcLogger lets go-plugin, which logs through hclog, log to a logger.Logger. Trace and debug messages go to Debugf,
+// info messages to Printf. A nil logger discards everything.
+type hcLogger struct {
+	log  logger.Logger
+	name string
+	args []interface{}
+}
+
+func newHCLogger(log logger.Logger, name string) hclog.Logger {
+	if l, ok := log.(*hclogLogger); ok && name != "" {
+		return l.log.Named(name)
+	} else if ok {
+		return l.log
+	}
+
+	return &hcLogger{log: log, name: name}
+}
+
+// write logs through logger.WriteRecord, so that loggers that take records get the arguments as fields.
+func (l *hcLogger) write(level logger.Level, msg string, args []interface{}) {
+	if l.log == nil {
+		return
+	}
+
+	if l.name != "" {
+		msg = l.name + ": " + msg
+	}
+
+	logger.WriteRecord(l.log, logger.Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Fields:  logger.Fields(append(append([]interface{}{}, l.args...), args...)...),
+	})
+}
+
+func (l *hcLogger) Trace(msg string, args ...interface{}) {
+	l.write(logger.DebugLevel, msg, args)
+}
+
+func (l *hcLogger) Debug(msg string, args ...interface{}) {
+	l.write(logger.DebugLevel, msg, args)
+}
+
+func (l *hcLogger) Info(msg string, args ...interface{}) {
+	l.write(logger.InfoLevel, msg, args)
+}
+
+func (l *hcLogger) Warn(msg string, args ...interface{}) {
+	l.write(logger.WarnLevel, msg, args)
+}
+
+func (l *hcLogger) Error(msg string, args ...interface{}) {
+	l.write(logger.ErrorLevel, msg, args)
+}
+
+func (l *hcLogger) IsTrace() bool { return l.log != nil }
+func (l *hcLogger) IsDebug() bool { return l.log != nil }
+func (l *hcLogger) IsInfo() bool  { return l.log != nil }
+func (l *hcLogger) IsWarn() bool  { return l.log != nil }
+func (l *hcLogger) IsError() bool { return l.log != nil }
+
+func (l *hcLogger) With(args ...interface{}) hclog.Logger {
+	return &hcLogger{log: l.log, name: l.name, args: append(append([]interface{}{}, l.args...), args...)}
+}
+
+func (l *hcLogger) Named(name string) hclog.Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+
+	return &hcLogger{log: l.log, name: name, args: l.args}
+}
+
+func (l *hcLogger) ResetNamed(name string) hclog.Logger {
+	return &hcLogger{log: l.log, name: name, args: l.args}
+}
+
+func (l *hcLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	if l.log == nil {
+		return log.New(ioutil.Discard, "", 0)
+	}
+
+	return log.New(&stdWriter{l}, "", 0)
+}
+
+type stdWriter struct {
+	log *hcLogger
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	w.log.Info(string(bytes.TrimRight(p, "\n")))
+
+	return len(p), nil
+}
+
+// NewHCLogLogger returns a logger that logs to `log`, for hosts that log with hclog. Records logged by plugins keep
+// their fields and caller; their time is replaced by that of hclog. hclog has no fatal or panic level, so those
+// messages are logged as errors, and Fatalf and Panicf neither exit nor panic.
+func NewHCLogLogger(log hclog.Logger) logger.StructuredLogger {
+	return &hclogLogger{log: log}
+}
+
+type hclogLogger struct {
+	log hclog.Logger
+}
+
+func (l *hclogLogger) LogRecord(record logger.Record) {
+	args := make([]interface{}, 0, 2*len(record.Fields)+2)
+
+	for _, field := range record.Fields {
+		args = append(args, field.Key, field.Value)
+	}
+
+	if record.Caller != "" {
+		args = append(args, "caller", record.Caller)
+	}
+
+	switch record.Level {
+	case logger.DebugLevel:
+		l.log.Debug(record.Message, args...)
+	case logger.InfoLevel:
+		l.log.Info(record.Message, args...)
+	case logger.WarnLevel:
+		l.log.Warn(record.Message, args...)
+	default:
+		l.log.Error(record.Message, args...)
+	}
+}
+
+// Level returns the lowest level that hclog logs, so that plugins drop the records that it would discard.
+func (l *hclogLogger) Level() logger.Level {
+	switch {
+	case l.log.IsDebug():
+		return logger.DebugLevel
+	case l.log.IsInfo():
+		return logger.InfoLevel
+	case l.log.IsWarn():
+		return logger.WarnLevel
+	}
+
+	return logger.ErrorLevel
+}
+
+func (l *hclogLogger) Log(level logger.Level, msg string, keyvals ...interface{}) {
+	l.LogRecord(logger.Record{Level: level, Message: msg, Fields: logger.Fields(keyvals...)})
+}
+
+func (l *hclogLogger) With(keyvals ...interface{}) logger.StructuredLogger {
+	return &hclogLogger{log: l.log.With(keyvals...)}
+}
+
+func (l *hclogLogger) Printf(format string, v ...interface{}) { l.log.Info(fmt.Sprintf(format, v...)) }
+func (l *hclogLogger) Fatalf(format string, v ...interface{}) { l.log.Error(fmt.Sprintf(format, v...)) }
+func (l *hclogLogger) Panicf(format string, v ...interface{}) { l.log.Error(fmt.Sprintf(format, v...)) }
+func (l *hclogLogger) Debugf(format string, v ...interface{}) { l.log.Debug(fmt.Sprintf(format, v...)) }
+func (l *hclogLogger) Warnf(format string, v ...interface{})  { l.log.Warn(fmt.Sprintf(format, v...)) }
+func (l *hclogLogger) Errorf(format string, v ...interface{}) { l.log.Error(fmt.Sprintf(format, v...)) }
@@ -0,0 +1,36 @@
+package host
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/unchainio/interfaces/adapter"
+	"github.com/unchainio/interfaces/logger"
+)
+
+func TestHCLogLogger(t *testing.T) {
+	var out bytes.Buffer
+
+	log := NewHCLogLogger(hclog.New(&hclog.LoggerOptions{Output: &out, Level: hclog.Debug}))
+	stub := adapter.NewStub(log)
+
+	logger.NewStructured(stub).With("table", "orders").Log(logger.WarnLevel, "polled", "count", 3)
+
+	line := out.String()
+
+	for _, s := range []string{"[WARN ]", "polled:", "table=orders", "count=3", "caller=host/hclog_test.go:"} {
+		if !strings.Contains(line, s) {
+			t.Fatalf("expected the hclog output to contain %q, got %q", s, line)
+		}
+	}
+}
+
+func TestHCLogLoggerLevel(t *testing.T) {
+	log := NewHCLogLogger(hclog.New(&hclog.LoggerOptions{Output: &bytes.Buffer{}, Level: hclog.Warn}))
+
+	if level := logger.LevelOf(adapter.NewStub(log)); level != logger.WarnLevel {
+		t.Fatalf("expected the stub to have the level of hclog, got %v", level)
+	}
+}
@@ -0,0 +1,209 @@
+// Package host launches endpoint and action plugin binaries and dispenses them as an adapter.Endpoint or
+// adapter.Action.
+package host
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/unchainio/interfaces/adapter"
+	"github.com/unchainio/interfaces/logger"
+)
+
+// Options configure how a plugin binary is launched. The zero value launches it without arguments and discards its
+// output.
+type Options struct {
+	// Args are passed to the plugin binary
+	Args []string
+
+	// Env is added to the environment of the host, which the plugin inherits
+	Env []string
+
+	// Logger receives everything the plugin writes to stderr, and the messages of the plugin client
+	Logger logger.Logger
+
+	// StartTimeout is how long the plugin may take to complete the handshake. Defaults to a minute.
+	StartTimeout time.Duration
+
+	// Limits bound the message bodies that the host receives from the plugin; the plugin sets its own limits
+	Limits adapter.Limits
+}
+
+func (o *Options) limits() adapter.Limits {
+	if o == nil {
+		return adapter.Limits{}
+	}
+
+	return o.Limits
+}
+
+// Endpoint is an endpoint plugin running in a process of its own. Closing it closes the endpoint and then ends the
+// process.
+type Endpoint struct {
+	*adapter.GRPCEndpointClient
+	client      *plugin.Client
+	description *adapter.Description
+}
+
+// LoadEndpoint launches the endpoint plugin binary at `path` and dispenses its endpoint. `opts` may be nil.
+func LoadEndpoint(path string, opts *Options) (*Endpoint, error) {
+	client, raw, err := load(path, opts, adapter.EndpointHandshake, map[string]plugin.Plugin{
+		"endpoint": &adapter.EndpointPlugin{Limits: opts.limits()},
+	}, "endpoint")
+
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, ok := raw.(*adapter.GRPCEndpointClient)
+
+	if !ok {
+		client.Kill()
+
+		return nil, fmt.Errorf("host: plugin %s dispensed a %T instead of an endpoint", path, raw)
+	}
+
+	description, err := endpoint.Describe(context.Background())
+
+	if err != nil {
+		client.Kill()
+
+		return nil, fmt.Errorf("host: failed to describe plugin %s: %v", path, err)
+	}
+
+	return &Endpoint{
+		GRPCEndpointClient: endpoint,
+		client:             client,
+		description:        description,
+	}, nil
+}
+
+func (e *Endpoint) Close(stub adapter.Stub) error {
+	return e.CloseContext(context.Background(), stub)
+}
+
+func (e *Endpoint) CloseContext(ctx context.Context, stub adapter.Stub) error {
+	defer e.Kill()
+
+	return e.GRPCEndpointClient.CloseContext(ctx, stub)
+}
+
+// Kill ends the plugin process without closing the endpoint first. It blocks until the process has exited.
+func (e *Endpoint) Kill() {
+	e.client.Kill()
+}
+
+// Exited reports whether the plugin process has exited.
+func (e *Endpoint) Exited() bool {
+	return e.client.Exited()
+}
+
+// Description returns what the plugin told about itself when it was loaded. Its Protocol is the newest protocol
+// version that both the host and the plugin speak.
+func (e *Endpoint) Description() *adapter.Description {
+	return e.description
+}
+
+// Action is an action plugin running in a process of its own.
+type Action struct {
+	*adapter.GRPCActionClient
+	client      *plugin.Client
+	description *adapter.Description
+}
+
+// LoadAction launches the action plugin binary at `path` and dispenses its action. `opts` may be nil.
+func LoadAction(path string, opts *Options) (*Action, error) {
+	client, raw, err := load(path, opts, adapter.ActionHandshake, map[string]plugin.Plugin{
+		"action": &adapter.ActionPlugin{Limits: opts.limits()},
+	}, "action")
+
+	if err != nil {
+		return nil, err
+	}
+
+	action, ok := raw.(*adapter.GRPCActionClient)
+
+	if !ok {
+		client.Kill()
+
+		return nil, fmt.Errorf("host: plugin %s dispensed a %T instead of an action", path, raw)
+	}
+
+	description, err := action.Describe(context.Background())
+
+	if err != nil {
+		client.Kill()
+
+		return nil, fmt.Errorf("host: failed to describe plugin %s: %v", path, err)
+	}
+
+	return &Action{
+		GRPCActionClient: action,
+		client:           client,
+		description:      description,
+	}, nil
+}
+
+// Close has the plugin deliver what it buffered for the stub of the action, stops serving that stub and ends the
+// plugin process. It blocks until the process has exited.
+func (a *Action) Close() error {
+	defer a.Kill()
+
+	return a.GRPCActionClient.Close()
+}
+
+// Kill ends the plugin process. It blocks until the process has exited.
+func (a *Action) Kill() {
+	a.client.Kill()
+}
+
+// Exited reports whether the plugin process has exited.
+func (a *Action) Exited() bool {
+	return a.client.Exited()
+}
+
+// Description returns what the plugin told about itself when it was loaded. Its Protocol is the newest protocol
+// version that both the host and the plugin speak.
+func (a *Action) Description() *adapter.Description {
+	return a.description
+}
+
+// load launches the plugin binary at `path`, completes the handshake and dispenses the plugin called `name`.
+func load(path string, opts *Options, handshake plugin.HandshakeConfig, plugins map[string]plugin.Plugin, name string) (*plugin.Client, interface{}, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	cmd := exec.Command(path, opts.Args...)
+	cmd.Env = append(cmd.Env, opts.Env...)
+
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  handshake,
+		Plugins:          plugins,
+		Cmd:              cmd,
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		StartTimeout:     opts.StartTimeout,
+		Logger:           newHCLogger(opts.Logger, ""),
+	})
+
+	protocol, err := client.Client()
+
+	if err != nil {
+		client.Kill()
+
+		return nil, nil, fmt.Errorf("host: failed to start plugin %s: %v", path, err)
+	}
+
+	raw, err := protocol.Dispense(name)
+
+	if err != nil {
+		client.Kill()
+
+		return nil, nil, fmt.Errorf("host: failed to dispense %s from plugin %s: %v", name, path, err)
+	}
+
+	return client, raw, nil
+}
@@ -0,0 +1,299 @@
+package host
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+	"github.com/unchainio/interfaces/adapter/stubtest"
+)
+
+// The test binary doubles as the plugin binary: when launched with testPluginEnv set, it serves the plugin named by
+// it instead of running the tests.
+const testPluginEnv = "ADAPTER_HOST_TEST_PLUGIN"
+
+var testManifest = adapter.Manifest{
+	Name:         "echo",
+	Version:      "1.0.0",
+	ConfigSchema: json.RawMessage(`{"type":"string"}`),
+}
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(testPluginEnv) {
+	case "endpoint":
+		adapter.StartEndpoint(&echoEndpoint{}, adapter.WithManifest(testManifest))
+		os.Exit(0)
+	case "action":
+		adapter.StartAction(&suffixAction{})
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// echoEndpoint responds with the message it is sent, and receives its config. It logs its config to stderr at Init,
+// exits when it is sent "crash", and calls Fatalf when it is sent "fatal". It counts the messages it echoes.
+type echoEndpoint struct {
+	config []byte
+}
+
+func (e *echoEndpoint) Init(stub adapter.Stub, config []byte) error {
+	log.Printf("init with %s", config)
+
+	e.config = config
+
+	return nil
+}
+
+func (e *echoEndpoint) Send(stub adapter.Stub, message *adapter.Message) (*adapter.Message, error) {
+	if string(message.Body) == "crash" {
+		os.Exit(1)
+	}
+
+	if string(message.Body) == "fatal" {
+		stub.Fatalf("fatal send")
+	}
+
+	stub.AddCounter("echoed_total", 1, nil)
+
+	return message, nil
+}
+
+func (e *echoEndpoint) Receive(stub adapter.Stub) (*adapter.TaggedMessage, error) {
+	return adapter.NewTaggedMessage(e.config), nil
+}
+
+func (e *echoEndpoint) Ack(stub adapter.Stub, tag uint64, response *adapter.Message) error {
+	return nil
+}
+
+func (e *echoEndpoint) Nack(stub adapter.Stub, tag uint64, err error) error { return nil }
+
+func (e *echoEndpoint) Close(stub adapter.Stub) error { return nil }
+
+// suffixAction appends its config to the body of every message, and logs it through the stub at Init.
+type suffixAction struct {
+	suffix []byte
+}
+
+func (a *suffixAction) Init(stub adapter.Stub, config []byte) error {
+	stub.Printf("init with suffix %s", config)
+
+	a.suffix = config
+
+	return nil
+}
+
+func (a *suffixAction) Invoke(stub adapter.Stub, message *adapter.Message) error {
+	message.Body = append(message.Body, a.suffix...)
+
+	return nil
+}
+
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) contains(s string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, line := range l.lines {
+		if strings.Contains(line, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) { l.record("INFO", format, v...) }
+func (l *recordingLogger) Fatalf(format string, v ...interface{}) { l.record("FATAL", format, v...) }
+func (l *recordingLogger) Panicf(format string, v ...interface{}) { l.record("PANIC", format, v...) }
+func (l *recordingLogger) Debugf(format string, v ...interface{}) { l.record("DEBUG", format, v...) }
+func (l *recordingLogger) Warnf(format string, v ...interface{})  { l.record("WARN", format, v...) }
+func (l *recordingLogger) Errorf(format string, v ...interface{}) { l.record("ERROR", format, v...) }
+
+func TestLoadEndpoint(t *testing.T) {
+	logger := &recordingLogger{}
+	metrics := adapter.NewMetricsRegistry()
+	stub := adapter.NewStub(logger, adapter.WithMetrics(metrics.With(adapter.Labels{"instance": "echo-1"})))
+
+	endpoint, err := LoadEndpoint(os.Args[0], &Options{
+		Env:    []string{testPluginEnv + "=endpoint"},
+		Logger: logger,
+	})
+
+	if err != nil {
+		t.Fatalf("failed to load endpoint: %v", err)
+	}
+
+	if endpoint.Description().Protocol() != adapter.ProtocolVersion {
+		t.Fatalf("expected protocol version %d, got %+v", adapter.ProtocolVersion, endpoint.Description())
+	}
+
+	if manifest := endpoint.Description().Manifest; manifest == nil || manifest.Name != "echo" || manifest.Kind != adapter.KindEndpoint {
+		t.Fatalf("expected the manifest of the echo endpoint, got %+v", manifest)
+	}
+
+	if err := endpoint.Init(stub, []byte("config")); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	response, err := endpoint.Send(stub, adapter.NewMessage([]byte("ping")))
+
+	if err != nil || string(response.Body) != "ping" {
+		t.Fatalf("expected response %q, got %v, %v", "ping", response, err)
+	}
+
+	if err := endpoint.Close(stub); err != nil {
+		t.Fatalf("failed to close endpoint: %v", err)
+	}
+
+	// the plugin delivers its buffered metrics when it is closed
+	var exposition bytes.Buffer
+	metrics.WritePrometheus(&exposition)
+
+	if expected := `echoed_total{instance="echo-1"} 1`; !strings.Contains(exposition.String(), expected) {
+		t.Fatalf("expected the metrics of the plugin to contain %q, got %q", expected, exposition.String())
+	}
+
+	if !endpoint.Exited() {
+		t.Fatalf("expected the plugin process to have exited after Close")
+	}
+
+	if !logger.contains("init with config") {
+		t.Fatalf("expected the stderr of the plugin to be logged, got %q", logger.lines)
+	}
+}
+
+func TestLoadEndpointFatalf(t *testing.T) {
+	logger := &recordingLogger{}
+	stub := adapter.NewStub(logger)
+
+	endpoint, err := LoadEndpoint(os.Args[0], &Options{
+		Env: []string{testPluginEnv + "=endpoint"},
+	})
+
+	if err != nil {
+		t.Fatalf("failed to load endpoint: %v", err)
+	}
+
+	defer endpoint.Kill()
+
+	if err := endpoint.Init(stub, nil); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	if _, err := endpoint.Send(stub, adapter.NewMessage([]byte("fatal"))); err == nil {
+		t.Fatalf("expected Send to fail once the plugin has exited")
+	}
+
+	if _, ok := endpoint.Unhealthy().(*adapter.FatalError); !ok {
+		t.Fatalf("expected the endpoint to be unhealthy, got %v", endpoint.Unhealthy())
+	}
+
+	if !logger.contains("ERROR fatal send plugin_level=FATAL") {
+		t.Fatalf("expected the host to log the fatal message as an error, got %q", logger.lines)
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); !endpoint.Exited(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the plugin process to exit after Fatalf")
+		}
+	}
+}
+
+func TestLoadAction(t *testing.T) {
+	stub := stubtest.New()
+
+	action, err := LoadAction(os.Args[0], &Options{
+		Env: []string{testPluginEnv + "=action"},
+	})
+
+	if err != nil {
+		t.Fatalf("failed to load action: %v", err)
+	}
+
+	defer action.Close()
+
+	if err := action.Init(stub, []byte("!")); err != nil {
+		t.Fatalf("failed to init action: %v", err)
+	}
+
+	// the plugin sends its logs in the background
+	for deadline := time.Now().Add(5 * time.Second); !stub.Logged(stubtest.InfoLevel, "init with suffix !"); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			stub.AssertLogged(t, stubtest.InfoLevel, "init with suffix !")
+		}
+	}
+
+	message := adapter.NewMessage([]byte("hello"))
+
+	if err := action.Invoke(stub, message); err != nil || string(message.Body) != "hello!" {
+		t.Fatalf("expected body %q, got %q, %v", "hello!", message.Body, err)
+	}
+}
+
+func TestLoadHandshakeMismatch(t *testing.T) {
+	action, err := LoadAction(os.Args[0], &Options{
+		Env: []string{testPluginEnv + "=endpoint"},
+	})
+
+	if err == nil {
+		action.Kill()
+
+		t.Fatalf("expected loading an endpoint plugin as an action to fail")
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	manifest, err := ReadManifest(os.Args[0], &Options{
+		Env: []string{testPluginEnv + "=endpoint"},
+	})
+
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	if manifest.Name != "echo" || manifest.Version != "1.0.0" || manifest.Kind != adapter.KindEndpoint {
+		t.Fatalf("expected the manifest of the echo endpoint, got %+v", manifest)
+	}
+
+	var schema bytes.Buffer
+
+	if err := json.Compact(&schema, manifest.ConfigSchema); err != nil || schema.String() != `{"type":"string"}` {
+		t.Fatalf("expected the config schema to be read, got %s", manifest.ConfigSchema)
+	}
+
+	for _, capability := range []adapter.Capability{adapter.CapabilityChunking, adapter.CapabilityKV, adapter.CapabilityMetrics} {
+		if !hasCapability(manifest.Capabilities, capability) {
+			t.Fatalf("expected capability %q, got %v", capability, manifest.Capabilities)
+		}
+	}
+}
+
+func hasCapability(capabilities []adapter.Capability, c adapter.Capability) bool {
+	for _, capability := range capabilities {
+		if capability == c {
+			return true
+		}
+	}
+
+	return false
+}
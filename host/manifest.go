@@ -0,0 +1,51 @@
+package host
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// ReadManifest runs the plugin binary at `path` with adapter.DescribeFlag and returns the manifest it prints, without
+// loading the plugin. `opts` may be nil; its Args are passed before the flag, and StartTimeout bounds how long the
+// binary may take.
+func ReadManifest(path string, opts *Options) (*adapter.Manifest, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	timeout := opts.StartTimeout
+
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path, append(opts.Args, adapter.DescribeFlag)...)
+	cmd.Env = append(os.Environ(), opts.Env...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		return nil, fmt.Errorf("host: failed to describe plugin %s: %v: %s", path, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	manifest := &adapter.Manifest{}
+
+	if err := json.Unmarshal(out, manifest); err != nil {
+		return nil, fmt.Errorf("host: plugin %s printed an invalid manifest: %v", path, err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,817 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+	"github.com/unchainio/interfaces/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrSupervisorClosed is returned by calls on a supervised plugin after it has been closed.
+var ErrSupervisorClosed = errors.New("host: supervised plugin is closed")
+
+// SupervisorOptions configure how a supervisor relaunches a plugin that died.
+type SupervisorOptions struct {
+	// MinBackoff is how long to wait before relaunching a plugin that failed to start again. Defaults to 100ms.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the backoff, which doubles after every failed launch. Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// OnNack is called for every tag that was in flight when an endpoint plugin died. These tags can no longer be
+	// Acked or Nacked, so the supervisor reports them as Nacked instead.
+	OnNack func(tag uint64, err error)
+}
+
+// SupervisorStats count what happened to a supervised plugin.
+type SupervisorStats struct {
+	// Crashes is the number of times the plugin process exited or its connection broke
+	Crashes uint64
+
+	// Restarts is the number of times the plugin was relaunched and initialized again
+	Restarts uint64
+
+	// Nacked is the number of in-flight tags that were reported as Nacked because of a crash
+	Nacked uint64
+}
+
+// exitPollInterval is how often the supervisor checks whether the plugin process has exited.
+const exitPollInterval = 50 * time.Millisecond
+
+// errExited is the reason of the crash of a plugin process that exited on its own.
+var errExited = errors.New("the plugin process exited")
+
+// process is a launched plugin.
+type process interface {
+	Kill()
+	Exited() bool
+	Describe(ctx context.Context) (*adapter.Description, error)
+	SetLogLevel(level logger.Level)
+	Unhealthy() error
+}
+
+// supervisor keeps a plugin process running. It watches the process and relaunches it in the background as soon as
+// it exits, backing off while launching fails; calls that find the process to have crashed report it as well.
+type supervisor struct {
+	launch  func() (process, error)
+	opts    *Options
+	backoff [2]time.Duration
+
+	// onCrash is called once for every crashed generation, after it has been killed
+	onCrash func(generation uint64, err error)
+
+	mu         sync.Mutex
+	current    process
+	generation uint64
+	ready      chan struct{}
+	done       chan struct{}
+	closed     bool
+	stats      SupervisorStats
+
+	// level is the log level set with SetLogLevel, if levelSet
+	level    logger.Level
+	levelSet bool
+}
+
+func newSupervisor(opts *Options, sopts *SupervisorOptions, launch func() (process, error)) *supervisor {
+	s := &supervisor{
+		launch:  launch,
+		opts:    opts,
+		backoff: [2]time.Duration{100 * time.Millisecond, 30 * time.Second},
+		done:    make(chan struct{}),
+	}
+
+	if sopts != nil && sopts.MinBackoff > 0 {
+		s.backoff[0] = sopts.MinBackoff
+	}
+
+	if sopts != nil && sopts.MaxBackoff > 0 {
+		s.backoff[1] = sopts.MaxBackoff
+	}
+
+	return s
+}
+
+// start makes `p` the current process of the first generation.
+func (s *supervisor) start(p process) {
+	s.mu.Lock()
+	s.current = p
+	s.mu.Unlock()
+
+	go s.watch(p, 0)
+}
+
+// watch reports the crash of the process of `generation` as soon as it exits, until it is replaced.
+func (s *supervisor) watch(p process, generation uint64) {
+	ticker := time.NewTicker(exitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return
+		}
+
+		if p.Exited() {
+			s.crash(generation, errExited)
+
+			return
+		}
+
+		s.mu.Lock()
+		replaced := s.generation != generation
+		s.mu.Unlock()
+
+		if replaced {
+			return
+		}
+	}
+}
+
+// get returns the current process and its generation, waiting for it to be relaunched if it is down.
+func (s *supervisor) get(ctx context.Context) (process, uint64, error) {
+	for {
+		s.mu.Lock()
+
+		if s.closed {
+			s.mu.Unlock()
+
+			return nil, 0, ErrSupervisorClosed
+		}
+
+		if s.current != nil {
+			p, generation := s.current, s.generation
+			s.mu.Unlock()
+
+			return p, generation, nil
+		}
+
+		ready := s.ready
+		s.mu.Unlock()
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
+}
+
+// crashed reports whether the call on `p` failed with `err` because the process died or its connection broke.
+func crashed(p process, err error) bool {
+	return p.Exited() || status.Code(err) == codes.Unavailable
+}
+
+// crash kills the process of `generation` and starts relaunching it, unless that generation has already been
+// replaced.
+func (s *supervisor) crash(generation uint64, err error) {
+	s.mu.Lock()
+
+	if s.closed || s.generation != generation || s.current == nil {
+		s.mu.Unlock()
+
+		return
+	}
+
+	p := s.current
+	s.current = nil
+	s.generation++
+	s.stats.Crashes++
+	s.ready = make(chan struct{})
+	s.mu.Unlock()
+
+	s.logf("plugin crashed, relaunching it: %v", err)
+	p.Kill()
+
+	if s.onCrash != nil {
+		s.onCrash(generation, err)
+	}
+
+	go s.relaunch()
+}
+
+// call runs `fn` on the current process. If the process crashed during the call, the crash is reported and the call
+// fails with a retryable *adapter.Error.
+func (s *supervisor) call(ctx context.Context, fn func(p process) error) error {
+	p, generation, err := s.get(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(p)
+
+	if err != nil && crashed(p, err) {
+		s.crash(generation, err)
+
+		return crashError(err)
+	}
+
+	return err
+}
+
+func (s *supervisor) relaunch() {
+	backoff := s.backoff[0]
+
+	for {
+		p, err := s.launch()
+
+		if err == nil {
+			s.mu.Lock()
+
+			if s.closed {
+				s.mu.Unlock()
+				p.Kill()
+
+				return
+			}
+
+			s.current = p
+			s.stats.Restarts++
+			close(s.ready)
+			generation := s.generation
+			s.mu.Unlock()
+
+			// again, in case the level was set while the plugin was being launched
+			s.setup(p)
+
+			go s.watch(p, generation)
+
+			return
+		}
+
+		s.logf("failed to relaunch plugin, retrying in %v: %v", backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-s.done:
+			return
+		}
+
+		if backoff *= 2; backoff > s.backoff[1] {
+			backoff = s.backoff[1]
+		}
+	}
+}
+
+// close stops relaunching and returns the current process, if any.
+func (s *supervisor) close() process {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	close(s.done)
+
+	p := s.current
+	s.current = nil
+
+	// Wake up the calls that are waiting for a relaunch
+	if p == nil && s.ready != nil {
+		close(s.ready)
+	}
+
+	return p
+}
+
+// setup applies the log level set with SetLogLevel to the newly launched `p`, before it is initialized.
+func (s *supervisor) setup(p process) {
+	s.mu.Lock()
+	level, ok := s.level, s.levelSet
+	s.mu.Unlock()
+
+	if ok {
+		p.SetLogLevel(level)
+	}
+}
+
+// SetLogLevel sets the lowest level that the plugin logs, like adapter.GRPCEndpointClient.SetLogLevel does. The level
+// is applied to every relaunched plugin as well.
+func (s *supervisor) SetLogLevel(level logger.Level) {
+	s.mu.Lock()
+	s.level, s.levelSet = level, true
+	p := s.current
+	s.mu.Unlock()
+
+	if p != nil {
+		p.SetLogLevel(level)
+	}
+}
+
+// Unhealthy returns the *adapter.FatalError of the first Fatalf or Panicf call of the current plugin process, and nil
+// if it made none or is being relaunched.
+func (s *supervisor) Unhealthy() error {
+	s.mu.Lock()
+	p := s.current
+	s.mu.Unlock()
+
+	if p == nil {
+		return nil
+	}
+
+	return p.Unhealthy()
+}
+
+// Describe describes the current plugin process, waiting for it to be relaunched if it is down.
+func (s *supervisor) Describe(ctx context.Context) (*adapter.Description, error) {
+	p, _, err := s.get(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Describe(ctx)
+}
+
+func (s *supervisor) Stats() SupervisorStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+func (s *supervisor) logf(format string, v ...interface{}) {
+	if s.opts != nil && s.opts.Logger != nil {
+		s.opts.Logger.Warnf(format, v...)
+	}
+}
+
+// crashError is returned from a call that was lost because the plugin crashed while handling it.
+func crashError(err error) error {
+	return adapter.NewRetryableError("plugin_crashed", fmt.Sprintf("the plugin crashed: %v", err), 0)
+}
+
+// SupervisedEndpoint is an endpoint plugin that is relaunched and initialized again with the same stub and config
+// whenever its process dies. A Receive or ReceiveStream that was interrupted by a crash is resumed on the relaunched
+// plugin; other interrupted calls fail with a retryable *adapter.Error.
+//
+// The tags of received messages are assigned by the supervisor, so that they stay unique across restarts. Tags that
+// were in flight during a crash are reported to SupervisorOptions.OnNack, and Acking or Nacking them afterwards fails
+// with a permanent *adapter.Error.
+type SupervisedEndpoint struct {
+	*supervisor
+	onNack func(tag uint64, err error)
+
+	mu          sync.Mutex
+	stub        adapter.Stub
+	config      []byte
+	initialized bool
+	nextTag     uint64
+	inflight    map[uint64]inflightTag
+}
+
+// inflightTag is the tag that a plugin generation gave to a received message.
+type inflightTag struct {
+	generation uint64
+	tag        uint64
+}
+
+// SuperviseEndpoint launches the endpoint plugin binary at `path` like LoadEndpoint does, and keeps it running.
+// `opts` and `sopts` may be nil.
+func SuperviseEndpoint(path string, opts *Options, sopts *SupervisorOptions) (*SupervisedEndpoint, error) {
+	e := &SupervisedEndpoint{
+		inflight: make(map[uint64]inflightTag),
+	}
+
+	if sopts != nil {
+		e.onNack = sopts.OnNack
+	}
+
+	e.supervisor = newSupervisor(opts, sopts, func() (process, error) {
+		endpoint, err := LoadEndpoint(path, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e.setup(endpoint)
+
+		e.mu.Lock()
+		stub, config, initialized := e.stub, e.config, e.initialized
+		e.mu.Unlock()
+
+		if initialized {
+			if err := endpoint.Init(stub, config); err != nil {
+				endpoint.Kill()
+
+				return nil, err
+			}
+		}
+
+		return endpoint, nil
+	})
+
+	e.onCrash = e.nackLost
+
+	endpoint, err := LoadEndpoint(path, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	e.start(endpoint)
+
+	return e, nil
+}
+
+func (e *SupervisedEndpoint) Init(stub adapter.Stub, config []byte) error {
+	return e.InitContext(context.Background(), stub, config)
+}
+
+func (e *SupervisedEndpoint) InitContext(ctx context.Context, stub adapter.Stub, config []byte) error {
+	e.mu.Lock()
+	e.stub, e.config, e.initialized = stub, config, true
+	e.mu.Unlock()
+
+	p, generation, err := e.get(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = p.(*Endpoint).InitContext(ctx, stub, config)
+
+	if err == nil || !crashed(p, err) {
+		return err
+	}
+
+	// The relaunched plugin is initialized before it becomes available, so waiting for it completes the Init
+	e.crash(generation, err)
+	_, _, err = e.get(ctx)
+
+	return err
+}
+
+func (e *SupervisedEndpoint) Send(stub adapter.Stub, message *adapter.Message) (*adapter.Message, error) {
+	return e.SendContext(context.Background(), stub, message)
+}
+
+func (e *SupervisedEndpoint) SendContext(ctx context.Context, stub adapter.Stub, message *adapter.Message) (response *adapter.Message, err error) {
+	err = e.call(ctx, func(p process) (err error) {
+		response, err = p.(*Endpoint).SendContext(ctx, stub, message)
+
+		return err
+	})
+
+	return response, err
+}
+
+func (e *SupervisedEndpoint) SendBatch(stub adapter.Stub, messages []*adapter.Message) ([]adapter.BatchResult, error) {
+	return e.SendBatchContext(context.Background(), stub, messages)
+}
+
+func (e *SupervisedEndpoint) SendBatchContext(ctx context.Context, stub adapter.Stub, messages []*adapter.Message) (results []adapter.BatchResult, err error) {
+	err = e.call(ctx, func(p process) (err error) {
+		results, err = p.(*Endpoint).SendBatchContext(ctx, stub, messages)
+
+		return err
+	})
+
+	return results, err
+}
+
+func (e *SupervisedEndpoint) Receive(stub adapter.Stub) (*adapter.TaggedMessage, error) {
+	return e.ReceiveContext(context.Background(), stub)
+}
+
+func (e *SupervisedEndpoint) ReceiveContext(ctx context.Context, stub adapter.Stub) (*adapter.TaggedMessage, error) {
+	for {
+		p, generation, err := e.get(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		message, err := p.(*Endpoint).ReceiveContext(ctx, stub)
+
+		if err != nil {
+			if crashed(p, err) {
+				e.crash(generation, err)
+
+				continue
+			}
+
+			return nil, err
+		}
+
+		message.Tag = e.track(generation, message.Tag)
+
+		return message, nil
+	}
+}
+
+func (e *SupervisedEndpoint) ReceiveStream(stub adapter.Stub, messages chan<- *adapter.TaggedMessage) error {
+	return e.ReceiveStreamContext(context.Background(), stub, messages)
+}
+
+// ReceiveStreamContext streams the messages that the plugin receives. A stream that was interrupted by a crash is
+// resumed on the relaunched plugin.
+func (e *SupervisedEndpoint) ReceiveStreamContext(ctx context.Context, stub adapter.Stub, messages chan<- *adapter.TaggedMessage) error {
+	for {
+		p, generation, err := e.get(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		err = e.forward(ctx, stub, p.(*Endpoint), generation, messages)
+
+		if err != nil && crashed(p, err) {
+			e.crash(generation, err)
+
+			continue
+		}
+
+		return err
+	}
+}
+
+// forward streams the messages that `endpoint` of `generation` receives to `messages`, with the tags of the
+// supervisor.
+func (e *SupervisedEndpoint) forward(ctx context.Context, stub adapter.Stub, endpoint *Endpoint, generation uint64, messages chan<- *adapter.TaggedMessage) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	received := make(chan *adapter.TaggedMessage)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- endpoint.ReceiveStreamContext(ctx, stub, received)
+	}()
+
+	for {
+		select {
+		case message := <-received:
+			message.Tag = e.track(generation, message.Tag)
+
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+				e.NackContext(context.Background(), stub, message.Tag, ctx.Err())
+
+				return e.drain(stub, generation, received, done, ctx.Err())
+			}
+		case err := <-done:
+			return err
+		}
+	}
+}
+
+// drain Nacks the messages that the plugin of `generation` still receives until its stream has ended, and returns
+// `err`.
+func (e *SupervisedEndpoint) drain(stub adapter.Stub, generation uint64, received <-chan *adapter.TaggedMessage, done <-chan error, err error) error {
+	for {
+		select {
+		case message := <-received:
+			e.NackContext(context.Background(), stub, e.track(generation, message.Tag), err)
+		case <-done:
+			return err
+		}
+	}
+}
+
+// track assigns a tag of the supervisor to the message that the plugin of `generation` received with `tag`.
+func (e *SupervisedEndpoint) track(generation uint64, tag uint64) uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nextTag++
+	e.inflight[e.nextTag] = inflightTag{generation: generation, tag: tag}
+
+	return e.nextTag
+}
+
+func (e *SupervisedEndpoint) Ack(stub adapter.Stub, tag uint64, response *adapter.Message) error {
+	return e.AckContext(context.Background(), stub, tag, response)
+}
+
+func (e *SupervisedEndpoint) AckContext(ctx context.Context, stub adapter.Stub, tag uint64, response *adapter.Message) error {
+	return e.settle(ctx, tag, func(endpoint *Endpoint, tag uint64) error {
+		return endpoint.AckContext(ctx, stub, tag, response)
+	})
+}
+
+func (e *SupervisedEndpoint) Nack(stub adapter.Stub, tag uint64, err error) error {
+	return e.NackContext(context.Background(), stub, tag, err)
+}
+
+func (e *SupervisedEndpoint) NackContext(ctx context.Context, stub adapter.Stub, tag uint64, err error) error {
+	return e.settle(ctx, tag, func(endpoint *Endpoint, tag uint64) error {
+		return endpoint.NackContext(ctx, stub, tag, err)
+	})
+}
+
+// settle Acks or Nacks the in-flight `tag` on the plugin generation that received it.
+func (e *SupervisedEndpoint) settle(ctx context.Context, tag uint64, fn func(endpoint *Endpoint, tag uint64) error) error {
+	e.mu.Lock()
+	t, ok := e.inflight[tag]
+	e.mu.Unlock()
+
+	if !ok {
+		return adapter.NewError("tag_lost", fmt.Sprintf("tag %d is not in flight; it may have been reported as Nacked after a crash", tag))
+	}
+
+	p, generation, err := e.get(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if generation != t.generation {
+		return adapter.NewError("tag_lost", fmt.Sprintf("tag %d was lost when the plugin crashed", tag))
+	}
+
+	err = fn(p.(*Endpoint), t.tag)
+
+	if err != nil && crashed(p, err) {
+		e.crash(generation, err)
+
+		return crashError(err)
+	}
+
+	e.mu.Lock()
+	delete(e.inflight, tag)
+	e.mu.Unlock()
+
+	return err
+}
+
+// nackLost reports the tags that the crashed `generation` had in flight as Nacked.
+func (e *SupervisedEndpoint) nackLost(generation uint64, err error) {
+	var lost []uint64
+
+	e.mu.Lock()
+
+	for tag, t := range e.inflight {
+		if t.generation == generation {
+			lost = append(lost, tag)
+			delete(e.inflight, tag)
+		}
+	}
+
+	e.mu.Unlock()
+
+	e.supervisor.mu.Lock()
+	e.stats.Nacked += uint64(len(lost))
+	e.supervisor.mu.Unlock()
+
+	if e.onNack == nil {
+		return
+	}
+
+	for _, tag := range lost {
+		e.onNack(tag, crashError(err))
+	}
+}
+
+func (e *SupervisedEndpoint) Close(stub adapter.Stub) error {
+	return e.CloseContext(context.Background(), stub)
+}
+
+// CloseContext closes the endpoint and ends the plugin process, and stops relaunching it.
+func (e *SupervisedEndpoint) CloseContext(ctx context.Context, stub adapter.Stub) error {
+	p := e.close()
+
+	if p == nil {
+		return nil
+	}
+
+	return p.(*Endpoint).CloseContext(ctx, stub)
+}
+
+// SupervisedAction is an action plugin that is relaunched and initialized again with the same stub and config
+// whenever its process dies. An Invoke that was interrupted by a crash fails with a retryable *adapter.Error.
+type SupervisedAction struct {
+	*supervisor
+
+	mu          sync.Mutex
+	stub        adapter.Stub
+	config      []byte
+	initialized bool
+}
+
+// SuperviseAction launches the action plugin binary at `path` like LoadAction does, and keeps it running. `opts`
+// and `sopts` may be nil; SupervisorOptions.OnNack is not used by actions.
+func SuperviseAction(path string, opts *Options, sopts *SupervisorOptions) (*SupervisedAction, error) {
+	a := &SupervisedAction{}
+
+	a.supervisor = newSupervisor(opts, sopts, func() (process, error) {
+		action, err := LoadAction(path, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		a.setup(action)
+
+		a.mu.Lock()
+		stub, config, initialized := a.stub, a.config, a.initialized
+		a.mu.Unlock()
+
+		if initialized {
+			if err := action.Init(stub, config); err != nil {
+				action.Kill()
+
+				return nil, err
+			}
+		}
+
+		return action, nil
+	})
+
+	action, err := LoadAction(path, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	a.start(action)
+
+	return a, nil
+}
+
+func (a *SupervisedAction) Init(stub adapter.Stub, config []byte) error {
+	return a.InitContext(context.Background(), stub, config)
+}
+
+func (a *SupervisedAction) InitContext(ctx context.Context, stub adapter.Stub, config []byte) error {
+	a.mu.Lock()
+	a.stub, a.config, a.initialized = stub, config, true
+	a.mu.Unlock()
+
+	p, generation, err := a.get(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = p.(*Action).InitContext(ctx, stub, config)
+
+	if err == nil || !crashed(p, err) {
+		return err
+	}
+
+	a.crash(generation, err)
+	_, _, err = a.get(ctx)
+
+	return err
+}
+
+func (a *SupervisedAction) Invoke(stub adapter.Stub, message *adapter.Message) error {
+	return a.InvokeContext(context.Background(), stub, message)
+}
+
+func (a *SupervisedAction) InvokeContext(ctx context.Context, stub adapter.Stub, message *adapter.Message) error {
+	return a.call(ctx, func(p process) error {
+		return p.(*Action).InvokeContext(ctx, stub, message)
+	})
+}
+
+func (a *SupervisedAction) InvokeMulti(stub adapter.Stub, message *adapter.Message) ([]*adapter.Message, error) {
+	return a.InvokeMultiContext(context.Background(), stub, message)
+}
+
+func (a *SupervisedAction) InvokeMultiContext(ctx context.Context, stub adapter.Stub, message *adapter.Message) (messages []*adapter.Message, err error) {
+	err = a.call(ctx, func(p process) (err error) {
+		messages, err = p.(*Action).InvokeMultiContext(ctx, stub, message)
+
+		return err
+	})
+
+	return messages, err
+}
+
+func (a *SupervisedAction) InvokeBatch(stub adapter.Stub, messages []*adapter.Message) ([]error, error) {
+	return a.InvokeBatchContext(context.Background(), stub, messages)
+}
+
+func (a *SupervisedAction) InvokeBatchContext(ctx context.Context, stub adapter.Stub, messages []*adapter.Message) (errs []error, err error) {
+	err = a.call(ctx, func(p process) (err error) {
+		errs, err = p.(*Action).InvokeBatchContext(ctx, stub, messages)
+
+		return err
+	})
+
+	return errs, err
+}
+
+// Close ends the plugin process and stops relaunching it.
+func (a *SupervisedAction) Close() error {
+	p := a.close()
+
+	if p == nil {
+		return nil
+	}
+
+	return p.(*Action).Close()
+}
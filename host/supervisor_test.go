@@ -0,0 +1,125 @@
+package host
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+func TestSuperviseEndpoint(t *testing.T) {
+	stub := adapter.NewStub(&recordingLogger{})
+
+	var (
+		mu     sync.Mutex
+		nacked []uint64
+	)
+
+	endpoint, err := SuperviseEndpoint(os.Args[0], &Options{
+		Env: []string{testPluginEnv + "=endpoint"},
+	}, &SupervisorOptions{
+		MinBackoff: 10 * time.Millisecond,
+		OnNack: func(tag uint64, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !adapter.IsRetryable(err) {
+				t.Errorf("expected a retryable error for tag %d, got %v", tag, err)
+			}
+
+			nacked = append(nacked, tag)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("failed to supervise endpoint: %v", err)
+	}
+
+	defer endpoint.Close(stub)
+
+	if err := endpoint.Init(stub, []byte("config")); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	inflight, err := endpoint.Receive(stub)
+
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+
+	if _, err := endpoint.Send(stub, adapter.NewMessage([]byte("crash"))); !adapter.IsRetryable(err) {
+		t.Fatalf("expected a retryable error from the Send that crashed the plugin, got %v", err)
+	}
+
+	// The relaunched plugin has been initialized with the original config again
+	received, err := endpoint.Receive(stub)
+
+	if err != nil || string(received.Body) != "config" {
+		t.Fatalf("expected to receive %q after the restart, got %v, %v", "config", received, err)
+	}
+
+	if received.Tag == inflight.Tag {
+		t.Fatalf("expected tags to stay unique across restarts, got %d twice", received.Tag)
+	}
+
+	if err := endpoint.Ack(stub, received.Tag, nil); err != nil {
+		t.Fatalf("failed to ack tag %d: %v", received.Tag, err)
+	}
+
+	if err := endpoint.Ack(stub, inflight.Tag, nil); !adapter.IsPermanent(err) {
+		t.Fatalf("expected a permanent error acking a tag lost in the crash, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(nacked) != 1 || nacked[0] != inflight.Tag {
+		t.Fatalf("expected tag %d to be reported as nacked, got %v", inflight.Tag, nacked)
+	}
+
+	stats := endpoint.Stats()
+
+	if stats != (SupervisorStats{Crashes: 1, Restarts: 1, Nacked: 1}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestSuperviseEndpointKilled(t *testing.T) {
+	stub := adapter.NewStub(&recordingLogger{})
+
+	endpoint, err := SuperviseEndpoint(os.Args[0], &Options{
+		Env: []string{testPluginEnv + "=endpoint"},
+	}, nil)
+
+	if err != nil {
+		t.Fatalf("failed to supervise endpoint: %v", err)
+	}
+
+	defer endpoint.Close(stub)
+
+	if err := endpoint.Init(stub, []byte("config")); err != nil {
+		t.Fatalf("failed to init endpoint: %v", err)
+	}
+
+	endpoint.current.Kill()
+
+	// The plugin is relaunched as soon as it exits, without waiting for a call to fail
+	for deadline := time.Now().Add(5 * time.Second); endpoint.Stats().Restarts != 1; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the killed plugin to be relaunched, got %+v", endpoint.Stats())
+		}
+	}
+
+	// Receive resumes on the relaunched plugin
+	received, err := endpoint.Receive(stub)
+
+	if err != nil || string(received.Body) != "config" {
+		t.Fatalf("expected to receive %q after the plugin was killed, got %v, %v", "config", received, err)
+	}
+
+	if stats := endpoint.Stats(); stats.Crashes != 1 || stats.Restarts != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
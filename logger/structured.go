@@ -0,0 +1,230 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Level is the severity of a log record.
+type Level int
+
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+	PanicLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	case FatalLevel:
+		return "FATAL"
+	case PanicLevel:
+		return "PANIC"
+	}
+
+	return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+}
+
+// Field is a key/value pair of a log record.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Fields pairs up alternating keys and values. Keys that aren't strings are formatted with fmt.Sprint, and a trailing
+// key without a value gets a nil value.
+func Fields(keyvals ...interface{}) []Field {
+	fields := make([]Field, 0, (len(keyvals)+1)/2)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		field := Field{Key: fmt.Sprint(keyvals[i])}
+
+		if i+1 < len(keyvals) {
+			field.Value = keyvals[i+1]
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// Record is a single structured log record.
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []Field
+
+	// Caller is the source location that logged the record, as file:line. It is empty if unknown.
+	Caller string
+}
+
+// String formats the message and fields of the record as `message key=value ...`, quoting values with spaces.
+func (r Record) String() string {
+	var b bytes.Buffer
+
+	b.WriteString(r.Message)
+
+	for _, field := range r.Fields {
+		value := fmt.Sprint(field.Value)
+
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+
+		fmt.Fprintf(&b, " %s=%s", field.Key, value)
+	}
+
+	return b.String()
+}
+
+// StructuredLogger is a Logger that also logs messages with key/value fields.
+type StructuredLogger interface {
+	Logger
+
+	// Log logs `msg` at `level`, with alternating keys and values as fields
+	Log(level Level, msg string, keyvals ...interface{})
+
+	// With returns a child logger that adds `keyvals` to the fields of everything it logs
+	With(keyvals ...interface{}) StructuredLogger
+}
+
+// RecordLogger is implemented by loggers that take complete records, keeping the fields, time and caller of records
+// that have been logged elsewhere, such as by a plugin.
+type RecordLogger interface {
+	LogRecord(record Record)
+}
+
+// LevelLogger is implemented by loggers that discard the records below a level, so that the records can be dropped
+// before they are even sent to them.
+type LevelLogger interface {
+	// Level returns the lowest level that the logger logs
+	Level() Level
+}
+
+// LevelOf returns the lowest level that `log` logs: its Level if it is a LevelLogger, and DebugLevel otherwise.
+func LevelOf(log Logger) Level {
+	if ll, ok := log.(LevelLogger); ok {
+		return ll.Level()
+	}
+
+	return DebugLevel
+}
+
+// WriteRecord logs `record` to `log`: as a record if it is a RecordLogger, and formatted with Record.String through the
+// printf-style method of its level otherwise.
+func WriteRecord(log Logger, record Record) {
+	if rl, ok := log.(RecordLogger); ok {
+		rl.LogRecord(record)
+
+		return
+	}
+
+	switch record.Level {
+	case DebugLevel:
+		log.Debugf("%s", record)
+	case WarnLevel:
+		log.Warnf("%s", record)
+	case ErrorLevel:
+		log.Errorf("%s", record)
+	case FatalLevel:
+		log.Fatalf("%s", record)
+	case PanicLevel:
+		log.Panicf("%s", record)
+	default:
+		log.Printf("%s", record)
+	}
+}
+
+// NewStructured returns `log` itself if it already is a StructuredLogger. Otherwise it returns a StructuredLogger that
+// writes every record to `log` with WriteRecord, recording the time and caller of each.
+func NewStructured(log Logger) StructuredLogger {
+	if sl, ok := log.(StructuredLogger); ok {
+		return sl
+	}
+
+	return &structured{log: log}
+}
+
+type structured struct {
+	log    Logger
+	fields []Field
+}
+
+// callerDepth is the number of frames from write up to the code that called a method of structured.
+const callerDepth = 2
+
+func (s *structured) write(level Level, msg string, fields []Field) {
+	WriteRecord(s.log, Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Fields:  append(append([]Field(nil), s.fields...), fields...),
+		Caller:  Caller(callerDepth),
+	})
+}
+
+func (s *structured) Log(level Level, msg string, keyvals ...interface{}) {
+	s.write(level, msg, Fields(keyvals...))
+}
+
+func (s *structured) With(keyvals ...interface{}) StructuredLogger {
+	return &structured{log: s.log, fields: append(append([]Field(nil), s.fields...), Fields(keyvals...)...)}
+}
+
+func (s *structured) Level() Level {
+	return LevelOf(s.log)
+}
+
+func (s *structured) Printf(format string, v ...interface{}) {
+	s.write(InfoLevel, fmt.Sprintf(format, v...), nil)
+}
+
+func (s *structured) Fatalf(format string, v ...interface{}) {
+	s.write(FatalLevel, fmt.Sprintf(format, v...), nil)
+}
+
+func (s *structured) Panicf(format string, v ...interface{}) {
+	s.write(PanicLevel, fmt.Sprintf(format, v...), nil)
+}
+
+func (s *structured) Debugf(format string, v ...interface{}) {
+	s.write(DebugLevel, fmt.Sprintf(format, v...), nil)
+}
+
+func (s *structured) Warnf(format string, v ...interface{}) {
+	s.write(WarnLevel, fmt.Sprintf(format, v...), nil)
+}
+
+func (s *structured) Errorf(format string, v ...interface{}) {
+	s.write(ErrorLevel, fmt.Sprintf(format, v...), nil)
+}
+
+// Caller returns the source location `skip` frames above the caller of Caller, as file:line with the directory of the
+// file trimmed to its last element.
+func Caller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+
+	if !ok {
+		return ""
+	}
+
+	return filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file)) + ":" + strconv.Itoa(line)
+}
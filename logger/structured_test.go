@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// printfLogger records every message it logs.
+type printfLogger struct {
+	lines []string
+}
+
+func (l *printfLogger) record(level, format string, v ...interface{}) {
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, v...))
+}
+
+func (l *printfLogger) Printf(format string, v ...interface{}) { l.record("INFO", format, v...) }
+func (l *printfLogger) Fatalf(format string, v ...interface{}) { l.record("FATAL", format, v...) }
+func (l *printfLogger) Panicf(format string, v ...interface{}) { l.record("PANIC", format, v...) }
+func (l *printfLogger) Debugf(format string, v ...interface{}) { l.record("DEBUG", format, v...) }
+func (l *printfLogger) Warnf(format string, v ...interface{})  { l.record("WARN", format, v...) }
+func (l *printfLogger) Errorf(format string, v ...interface{}) { l.record("ERROR", format, v...) }
+
+// capturingLogger keeps the records it is given.
+type capturingLogger struct {
+	printfLogger
+	records []Record
+}
+
+func (l *capturingLogger) LogRecord(record Record) {
+	l.records = append(l.records, record)
+}
+
+func TestNewStructured(t *testing.T) {
+	plain := &printfLogger{}
+
+	NewStructured(plain).With("table", "orders").Log(WarnLevel, "polled", "count", 3, "query", "a b")
+
+	if expected := `WARN polled table=orders count=3 query="a b"`; len(plain.lines) != 1 || plain.lines[0] != expected {
+		t.Fatalf("expected %q, got %q", expected, plain.lines)
+	}
+
+	capturing := &capturingLogger{}
+	log := NewStructured(capturing)
+
+	log.With("table", "orders").Log(ErrorLevel, "failed", "dangling")
+	log.Printf("done")
+
+	if len(capturing.records) != 2 {
+		t.Fatalf("expected 2 records, got %+v", capturing.records)
+	}
+
+	record := capturing.records[0]
+
+	if record.Level != ErrorLevel || len(record.Fields) != 2 || record.Fields[1] != (Field{Key: "dangling"}) {
+		t.Fatalf("expected an error with the fields of the child logger, got %+v", record)
+	}
+
+	for _, record := range capturing.records {
+		if record.Time.IsZero() || !strings.HasPrefix(record.Caller, "logger/structured_test.go:") {
+			t.Fatalf("expected the time and caller of the record, got %v and %q", record.Time, record.Caller)
+		}
+	}
+
+	if capturing.records[1].Level != InfoLevel || capturing.records[1].Message != "done" {
+		t.Fatalf("expected Printf to log at the info level, got %+v", capturing.records[1])
+	}
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// The built-in metrics of a pipeline. Endpoint metrics are labelled with the endpoint, "input" or "output", and the
+// operation, "receive", "send", "ack" or "nack". Action metrics are labelled with the chain, "request" or "response",
+// and the index of the action in it.
+const (
+	MetricMessagesReceived = "pipeline_messages_received_total"
+	MetricMessagesSent     = "pipeline_messages_sent_total"
+	MetricMessagesAcked    = "pipeline_messages_acked_total"
+	MetricMessagesNacked   = "pipeline_messages_nacked_total"
+
+	// MetricMessageDuration is the time from receiving a message until it has been Acked or Nacked
+	MetricMessageDuration = "pipeline_message_duration_seconds"
+
+	MetricEndpointErrors   = "pipeline_endpoint_errors_total"
+	MetricEndpointDuration = "pipeline_endpoint_duration_seconds"
+
+	MetricActionInvocations = "pipeline_action_invocations_total"
+	MetricActionErrors      = "pipeline_action_errors_total"
+	MetricActionDuration    = "pipeline_action_duration_seconds"
+)
+
+const (
+	input  = "input"
+	output = "output"
+
+	requestChain  = "request"
+	responseChain = "response"
+)
+
+// counters of the successful endpoint operations, by operation
+var endpointCounters = map[string]string{
+	"receive": MetricMessagesReceived,
+	"send":    MetricMessagesSent,
+	"ack":     MetricMessagesAcked,
+	"nack":    MetricMessagesNacked,
+}
+
+// countEndpoint records the outcome of an endpoint operation.
+func (p *Pipeline) countEndpoint(endpoint, operation string, err error) {
+	labels := adapter.Labels{"endpoint": endpoint, "operation": operation}
+
+	if err != nil {
+		p.metrics.AddCounter(MetricEndpointErrors, 1, labels)
+
+		return
+	}
+
+	p.metrics.AddCounter(endpointCounters[operation], 1, adapter.Labels{"endpoint": endpoint})
+}
+
+// timeEndpoint records the outcome and latency of an endpoint operation that started at `start`.
+func (p *Pipeline) timeEndpoint(endpoint, operation string, start time.Time, err error) {
+	p.metrics.ObserveHistogram(MetricEndpointDuration, time.Since(start).Seconds(), adapter.Labels{"endpoint": endpoint, "operation": operation})
+	p.countEndpoint(endpoint, operation, err)
+}
+
+// timeAction records the outcome and latency of invoking the action at `index` of `chain`.
+func (p *Pipeline) timeAction(chain string, index int, start time.Time, err error) {
+	labels := adapter.Labels{"chain": chain, "action": strconv.Itoa(index)}
+
+	p.metrics.ObserveHistogram(MetricActionDuration, time.Since(start).Seconds(), labels)
+	p.metrics.AddCounter(MetricActionInvocations, 1, labels)
+
+	if err != nil {
+		p.metrics.AddCounter(MetricActionErrors, 1, labels)
+	}
+}
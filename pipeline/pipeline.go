@@ -0,0 +1,283 @@
+// Package pipeline is the adapter base: it receives messages from an input endpoint, passes them through a chain of
+// actions, sends them over an output endpoint, passes the response through a chain of response actions, and finally
+// Acks the message on the input endpoint, or Nacks it if anything went wrong along the way.
+//
+// Actions that implement adapter.MultiAction can drop a message or turn it into several. Each of those messages is
+// sent and passed through the response actions on its own, and the received message is Acked once all of them have
+// been processed, or Nacked as soon as one of them fails. Messages are therefore delivered at least once: when a
+// message is Nacked after some of its messages have been sent, these are sent again if the input endpoint redelivers
+// it.
+//
+// Pipelines record the number of messages received, sent, Acked and Nacked, the latencies of the endpoints and
+// actions, and their errors, as the metrics named by the Metric constants.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+// Config wires the parts of a pipeline together. The endpoints and actions must have been initialized already, and
+// are not closed by the pipeline.
+type Config struct {
+	Input           adapter.Endpoint
+	Actions         []adapter.Action
+	Output          adapter.Endpoint
+	ResponseActions []adapter.Action
+
+	// Stub is passed to every call. Plugins dispensed over gRPC keep using the stub they have been initialized with.
+	Stub adapter.Stub
+
+	// Metrics records the built-in metrics of the pipeline. Defaults to Stub.
+	Metrics adapter.Metrics
+
+	// Concurrency is the maximum number of messages that have been received but not yet Acked or Nacked. Defaults to 1,
+	// which processes the messages one by one in the order they were received.
+	Concurrency int
+
+	// ShutdownTimeout is how long Run waits for in-flight messages once its context is done, before cancelling them.
+	// Cancelled messages are still Nacked, each within SettleTimeout. 0 waits until they are done.
+	ShutdownTimeout time.Duration
+
+	// ReceiveBackoff is how long to wait before receiving again after Receive failed with an error that doesn't
+	// specify a RetryAfter. Defaults to a second.
+	ReceiveBackoff time.Duration
+}
+
+// SettleTimeout bounds every Ack and Nack call. Messages are settled with a context of their own, so that messages
+// cancelled by the ShutdownTimeout can still be Nacked.
+const SettleTimeout = 5 * time.Second
+
+type Pipeline struct {
+	input           adapter.ContextEndpoint
+	actions         []adapter.Action
+	output          adapter.ContextEndpoint
+	responseActions []adapter.Action
+	stub            adapter.Stub
+	metrics         adapter.Metrics
+
+	concurrency     int
+	shutdownTimeout time.Duration
+	receiveBackoff  time.Duration
+}
+
+func New(cfg Config) (*Pipeline, error) {
+	if cfg.Input == nil || cfg.Output == nil {
+		return nil, errors.New("pipeline: an input and an output endpoint are required")
+	}
+
+	if cfg.Stub == nil {
+		return nil, errors.New("pipeline: a stub is required")
+	}
+
+	p := &Pipeline{
+		input:           adapter.NewContextEndpoint(cfg.Input),
+		actions:         cfg.Actions,
+		output:          adapter.NewContextEndpoint(cfg.Output),
+		responseActions: cfg.ResponseActions,
+		stub:            cfg.Stub,
+		metrics:         cfg.Metrics,
+		concurrency:     cfg.Concurrency,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		receiveBackoff:  cfg.ReceiveBackoff,
+	}
+
+	if p.metrics == nil {
+		p.metrics = cfg.Stub
+	}
+
+	if p.concurrency < 1 {
+		p.concurrency = 1
+	}
+
+	if p.receiveBackoff == 0 {
+		p.receiveBackoff = time.Second
+	}
+
+	return p, nil
+}
+
+// Run receives and processes messages until `ctx` is done, or until Receive fails with a permanent error, which is
+// returned. Either way, Run stops receiving and waits for the messages in flight to be Acked or Nacked before
+// returning.
+func (p *Pipeline) Run(ctx context.Context) error {
+	// in-flight messages are processed with a context of their own, so that they can finish after `ctx` is done
+	processCtx, cancelProcessing := context.WithCancel(context.Background())
+	defer cancelProcessing()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, p.concurrency)
+
+	err := p.receive(ctx, func(message *adapter.TaggedMessage) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			p.process(processCtx, message)
+		}()
+	}, slots)
+
+	if p.shutdownTimeout > 0 {
+		timer := time.AfterFunc(p.shutdownTimeout, cancelProcessing)
+		defer timer.Stop()
+	}
+
+	wg.Wait()
+
+	return err
+}
+
+// receive hands every received message to `process` after taking a slot, until `ctx` is done or Receive fails
+// permanently.
+func (p *Pipeline) receive(ctx context.Context, process func(message *adapter.TaggedMessage), slots chan struct{}) error {
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+
+		message, err := p.input.ReceiveContext(ctx, p.stub)
+
+		if err != nil {
+			<-slots
+
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			p.countEndpoint(input, "receive", err)
+
+			if adapter.IsPermanent(err) {
+				return err
+			}
+
+			p.stub.Errorf("pipeline: failed to receive a message: %v", err)
+
+			backoff := p.receiveBackoff
+
+			if e, ok := adapter.AsError(err); ok && e.RetryAfter > 0 {
+				backoff = e.RetryAfter
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil
+			}
+
+			continue
+		}
+
+		p.countEndpoint(input, "receive", nil)
+		process(message)
+	}
+}
+
+// process passes the message through the pipeline and Acks or Nacks it. The message is Acked with its response if
+// it resulted in exactly one, and with a nil response otherwise.
+func (p *Pipeline) process(ctx context.Context, message *adapter.TaggedMessage) {
+	received := time.Now()
+	defer func() { p.metrics.ObserveHistogram(MetricMessageDuration, time.Since(received).Seconds(), nil) }()
+
+	responses, err := p.handle(ctx, message.Message)
+
+	if err != nil {
+		p.stub.Errorf("pipeline: failed to process message %s (tag %d): %v", message.ID, message.Tag, err)
+
+		settleCtx, cancel := context.WithTimeout(context.Background(), SettleTimeout)
+		defer cancel()
+
+		start := time.Now()
+		err := p.input.NackContext(settleCtx, p.stub, message.Tag, err)
+		p.timeEndpoint(input, "nack", start, err)
+
+		if err != nil {
+			p.stub.Errorf("pipeline: failed to nack message %s (tag %d): %v", message.ID, message.Tag, err)
+		}
+
+		return
+	}
+
+	var response *adapter.Message
+
+	if len(responses) == 1 {
+		response = responses[0]
+	}
+
+	settleCtx, cancel := context.WithTimeout(context.Background(), SettleTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err = p.input.AckContext(settleCtx, p.stub, message.Tag, response)
+	p.timeEndpoint(input, "ack", start, err)
+
+	if err != nil {
+		p.stub.Errorf("pipeline: failed to ack message %s (tag %d): %v", message.ID, message.Tag, err)
+	}
+}
+
+// handle returns the responses to all the messages that the actions turned `message` into.
+func (p *Pipeline) handle(ctx context.Context, message *adapter.Message) ([]*adapter.Message, error) {
+	messages, err := p.invoke(ctx, requestChain, p.actions, []*adapter.Message{message})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*adapter.Message
+
+	for _, message := range messages {
+		start := time.Now()
+		response, err := p.output.SendContext(ctx, p.stub, message)
+		p.timeEndpoint(output, "send", start, err)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if response == nil {
+			response = adapter.NewMessage(nil)
+		}
+
+		invoked, err := p.invoke(ctx, responseChain, p.responseActions, []*adapter.Message{response})
+
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, invoked...)
+	}
+
+	return responses, nil
+}
+
+// invoke passes `messages` through `actions`, the actions of `chain`, each of which replaces every message with the
+// messages it returns.
+func (p *Pipeline) invoke(ctx context.Context, chain string, actions []adapter.Action, messages []*adapter.Message) ([]*adapter.Message, error) {
+	for i, action := range actions {
+		var invoked []*adapter.Message
+
+		for _, message := range messages {
+			start := time.Now()
+			replaced, err := adapter.InvokeMulti(ctx, action, p.stub, message)
+			p.timeAction(chain, i, start, err)
+
+			if err != nil {
+				return nil, err
+			}
+
+			invoked = append(invoked, replaced...)
+		}
+
+		messages = invoked
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,328 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unchainio/interfaces/adapter"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, v ...interface{}) {}
+func (nopLogger) Fatalf(format string, v ...interface{}) {}
+func (nopLogger) Panicf(format string, v ...interface{}) {}
+func (nopLogger) Debugf(format string, v ...interface{}) {}
+func (nopLogger) Warnf(format string, v ...interface{})  {}
+func (nopLogger) Errorf(format string, v ...interface{}) {}
+
+// inputEndpoint receives the messages put on its queue and records how every tag ended.
+type inputEndpoint struct {
+	queue chan *adapter.TaggedMessage
+
+	mu    sync.Mutex
+	acks  map[uint64]string
+	nacks map[uint64]error
+	done  chan struct{}
+	left  int
+}
+
+func newInputEndpoint(bodies ...string) *inputEndpoint {
+	e := &inputEndpoint{
+		queue: make(chan *adapter.TaggedMessage, len(bodies)),
+		acks:  make(map[uint64]string),
+		nacks: make(map[uint64]error),
+		done:  make(chan struct{}),
+		left:  len(bodies),
+	}
+
+	for i, body := range bodies {
+		e.queue <- adapter.NewTaggedMessage([]byte(body), adapter.WithTag(uint64(i+1)))
+	}
+
+	return e
+}
+
+func (e *inputEndpoint) Init(stub adapter.Stub, config []byte) error { return nil }
+
+func (e *inputEndpoint) Send(stub adapter.Stub, message *adapter.Message) (*adapter.Message, error) {
+	return nil, errors.New("input only")
+}
+
+func (e *inputEndpoint) Receive(stub adapter.Stub) (*adapter.TaggedMessage, error) {
+	return <-e.queue, nil
+}
+
+func (e *inputEndpoint) Ack(stub adapter.Stub, tag uint64, response *adapter.Message) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if response == nil {
+		e.acks[tag] = "<nil>"
+	} else {
+		e.acks[tag] = string(response.Body)
+	}
+
+	e.settle()
+
+	return nil
+}
+
+func (e *inputEndpoint) Nack(stub adapter.Stub, tag uint64, err error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nacks[tag] = err
+	e.settle()
+
+	return nil
+}
+
+func (e *inputEndpoint) settle() {
+	if e.left--; e.left == 0 {
+		close(e.done)
+	}
+}
+
+func (e *inputEndpoint) Close(stub adapter.Stub) error { return nil }
+
+// outputEndpoint responds with the upper-cased body of every message it is sent, and fails on "fail". If `started`
+// is set, Send signals on it when it starts; if `release` is set, Send blocks until it is closed.
+type outputEndpoint struct {
+	inputEndpoint
+	started chan struct{}
+	release chan struct{}
+	sent    []string
+}
+
+func (e *outputEndpoint) Send(stub adapter.Stub, message *adapter.Message) (*adapter.Message, error) {
+	if e.started != nil {
+		e.started <- struct{}{}
+	}
+
+	if e.release != nil {
+		<-e.release
+	}
+
+	if string(message.Body) == "fail" {
+		return nil, adapter.NewError("rejected", "fail")
+	}
+
+	e.mu.Lock()
+	e.sent = append(e.sent, string(message.Body))
+	e.mu.Unlock()
+
+	return adapter.NewMessage([]byte(strings.ToUpper(string(message.Body)))), nil
+}
+
+type suffixAction string
+
+func (a suffixAction) Init(stub adapter.Stub, config []byte) error { return nil }
+
+func (a suffixAction) Invoke(stub adapter.Stub, message *adapter.Message) error {
+	message.Body = append(message.Body, a...)
+
+	return nil
+}
+
+// splitAction splits the body of every message on commas, and drops messages with an empty body.
+type splitAction struct {
+	suffixAction
+}
+
+func (a splitAction) InvokeMulti(stub adapter.Stub, message *adapter.Message) ([]*adapter.Message, error) {
+	var messages []*adapter.Message
+
+	if len(message.Body) == 0 {
+		return nil, nil
+	}
+
+	for _, body := range strings.Split(string(message.Body), ",") {
+		messages = append(messages, adapter.NewMessage([]byte(body)))
+	}
+
+	return messages, nil
+}
+
+func TestPipeline(t *testing.T) {
+	input := newInputEndpoint("a", "fail", "b")
+	metrics := adapter.NewMetricsRegistry()
+
+	p, err := New(Config{
+		Input:           input,
+		Actions:         []adapter.Action{suffixAction("")},
+		Output:          &outputEndpoint{},
+		ResponseActions: []adapter.Action{suffixAction("!")},
+		Stub:            adapter.NewStub(nopLogger{}),
+		Metrics:         metrics,
+		Concurrency:     2,
+	})
+
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- p.Run(ctx)
+	}()
+
+	<-input.done
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected the pipeline to stop without error, got %v", err)
+	}
+
+	if input.acks[1] != "A!" || input.acks[3] != "B!" {
+		t.Fatalf("expected tags 1 and 3 to be acked with %q and %q, got %v", "A!", "B!", input.acks)
+	}
+
+	if !adapter.IsPermanent(input.nacks[2]) {
+		t.Fatalf("expected tag 2 to be nacked with the error of the output, got %v", input.nacks)
+	}
+
+	for _, expected := range []struct {
+		name   string
+		labels adapter.Labels
+		value  float64
+	}{
+		{MetricMessagesReceived, adapter.Labels{"endpoint": "input"}, 3},
+		{MetricMessagesSent, adapter.Labels{"endpoint": "output"}, 2},
+		{MetricMessagesAcked, adapter.Labels{"endpoint": "input"}, 2},
+		{MetricMessagesNacked, adapter.Labels{"endpoint": "input"}, 1},
+		{MetricEndpointErrors, adapter.Labels{"endpoint": "output", "operation": "send"}, 1},
+		{MetricEndpointDuration, adapter.Labels{"endpoint": "output", "operation": "send"}, 3},
+		{MetricActionInvocations, adapter.Labels{"chain": "request", "action": "0"}, 3},
+		{MetricActionInvocations, adapter.Labels{"chain": "response", "action": "0"}, 2},
+		{MetricMessageDuration, nil, 3},
+	} {
+		if value := metrics.Value(expected.name, expected.labels); value != expected.value {
+			t.Errorf("expected %s%v to be %v, got %v", expected.name, expected.labels, expected.value, value)
+		}
+	}
+}
+
+func TestPipelineGracefulShutdown(t *testing.T) {
+	input := newInputEndpoint("slow")
+	output := &outputEndpoint{started: make(chan struct{}, 1), release: make(chan struct{})}
+
+	p, err := New(Config{
+		Input:  input,
+		Output: output,
+		Stub:   adapter.NewStub(nopLogger{}),
+	})
+
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- p.Run(ctx)
+	}()
+
+	<-output.started
+	cancel()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected Run to wait for the in-flight message, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(output.release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected the pipeline to stop without error, got %v", err)
+	}
+
+	if input.acks[1] != "SLOW" {
+		t.Fatalf("expected the in-flight message to be acked before Run returned, got %v", input.acks)
+	}
+}
+
+func TestPipelineShutdownTimeout(t *testing.T) {
+	input := newInputEndpoint("stuck")
+	output := &outputEndpoint{started: make(chan struct{}, 1), release: make(chan struct{})}
+	defer close(output.release)
+
+	p, err := New(Config{
+		Input:           input,
+		Output:          output,
+		Stub:            adapter.NewStub(nopLogger{}),
+		ShutdownTimeout: 10 * time.Millisecond,
+	})
+
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- p.Run(ctx)
+	}()
+
+	<-output.started
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected the pipeline to stop without error, got %v", err)
+	}
+
+	if input.nacks[1] != context.Canceled {
+		t.Fatalf("expected the cancelled message to be nacked, got acks %v and nacks %v", input.acks, input.nacks)
+	}
+}
+
+func TestPipelineMultiAction(t *testing.T) {
+	input := newInputEndpoint("a,b", "", "c,fail,d", "e")
+	output := &outputEndpoint{}
+
+	p, err := New(Config{
+		Input:   input,
+		Actions: []adapter.Action{splitAction{}},
+		Output:  output,
+		Stub:    adapter.NewStub(nopLogger{}),
+	})
+
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- p.Run(ctx)
+	}()
+
+	<-input.done
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected the pipeline to stop without error, got %v", err)
+	}
+
+	if input.acks[1] != "<nil>" || input.acks[2] != "<nil>" || input.acks[4] != "E" {
+		t.Fatalf("expected tags 1 and 2 to be acked without a response and tag 4 with %q, got %v", "E", input.acks)
+	}
+
+	if len(input.nacks) != 1 || input.nacks[3] == nil {
+		t.Fatalf("expected only tag 3 to be nacked, got %v", input.nacks)
+	}
+
+	if sent := strings.Join(output.sent, " "); sent != "a b c e" {
+		t.Fatalf("expected %q to be sent, got %q", "a b c e", sent)
+	}
+}